@@ -0,0 +1,176 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/types"
+	"strings"
+)
+
+var OutPackageError = errors.New("unable to write into output package")
+
+// outputPackage package of the generated file
+func outputPackage(cfg Config) string {
+	if cfg.Mode == ReexportMode {
+		return cfg.Target
+	}
+	if cfg.OutPackage != "" {
+		return cfg.OutPackage
+	}
+	return cfg.Package
+}
+
+// checkOutPackage checks that target may be referenced from the output package
+func checkOutPackage(cfg Config) error {
+	out := outputPackage(cfg)
+	if !ast.IsExported(cfg.Target) {
+		return fmt.Errorf("%w %s: target %s is unexported", OutPackageError, out, cfg.Target)
+	}
+	if !importable(cfg.Package, out) {
+		return fmt.Errorf("%w %s: %s may not be imported", OutPackageError, out, cfg.Package)
+	}
+	return nil
+}
+
+// accessibleContent drops functions which refers to the types (or members) that output package may not reference,
+// every dropped function is reported with position of its declaration, in strict mode first of them is an error
+func accessibleContent(run *run, cfg Config, content []*wrappedFunctionDeclaration, strict bool) ([]*wrappedFunctionDeclaration, error) {
+	out := outputPackage(cfg)
+	result := make([]*wrappedFunctionDeclaration, 0, len(content))
+	for _, fn := range content {
+		if reason := inaccessibleFunction(fn, cfg.Package, out); reason != "" {
+			err := fmt.Errorf("%s: %w %s: %s %s", position(run, fn), OutPackageError, out, fn.Name, reason)
+			if strict {
+				return nil, err
+			}
+			run.diagnose(fmt.Sprintf("%s, skipped", err))
+			continue
+		}
+		result = append(result, fn)
+	}
+	return result, nil
+}
+
+func inaccessibleFunction(fn *wrappedFunctionDeclaration, root, out string) string {
+	if root != out { //members are selected from the variable of the root target
+		for _, prefix := range fn.CallPrefix {
+			if !ast.IsExported(prefix) {
+				return fmt.Sprintf("is promoted via unexported member %s", prefix)
+			}
+		}
+	}
+	signature := fn.signature()
+	if signature == nil {
+		return "" //unresolved types, reference to the inaccessible one fails the build of the output package
+	}
+	if name := inaccessibleType(signature, out, map[types.Type]bool{}); name != "" {
+		return fmt.Sprintf("refers to %s", name)
+	}
+	return ""
+}
+
+// position of the function declaration, package if it is unknown
+func position(run *run, fn *wrappedFunctionDeclaration) string {
+	record, ok := run.loaded(fn.Package)
+	if !ok || record.fileSet == nil || fn.Signature == nil || !fn.Signature.Pos().IsValid() {
+		return fn.Package
+	}
+	return record.fileSet.Position(fn.Signature.Pos()).String()
+}
+
+// importable reports whether package may be imported by the package from, see internal packages rule
+func importable(pkg, from string) bool {
+	if pkg == from {
+		return true
+	}
+	parts := strings.Split(pkg, "/")
+	for i := len(parts) - 1; i >= 0; i-- {
+		if parts[i] == "internal" {
+			root := strings.Join(parts[:i], "/")
+			if root == "" { //internal of the standard library
+				return !strings.Contains(strings.Split(from, "/")[0], ".")
+			}
+			return from == root || strings.HasPrefix(from, root+"/")
+		}
+	}
+	return true
+}
+
+// inaccessibleType name of the type which may not be referenced from the package, empty if there is no one
+func inaccessibleType(typeOf types.Type, from string, seen map[types.Type]bool) string {
+	if seen[typeOf] {
+		return ""
+	}
+	seen[typeOf] = true
+	if object, aliased, ok := aliasOf(typeOf); ok {
+		//alias of the other package is referenced by its own name
+		if name := inaccessibleName(object, from); name != "" {
+			return name
+		}
+		return inaccessibleType(aliased, from, seen)
+	}
+	switch t := typeOf.(type) {
+	case *types.Named:
+		if name := inaccessibleName(t.Obj(), from); name != "" {
+			return name
+		}
+		if t.TypeArgs() != nil {
+			for i := 0; i < t.TypeArgs().Len(); i++ {
+				if name := inaccessibleType(t.TypeArgs().At(i), from, seen); name != "" {
+					return name
+				}
+			}
+		}
+	case *types.Pointer:
+		return inaccessibleType(t.Elem(), from, seen)
+	case *types.Slice:
+		return inaccessibleType(t.Elem(), from, seen)
+	case *types.Array:
+		return inaccessibleType(t.Elem(), from, seen)
+	case *types.Chan:
+		return inaccessibleType(t.Elem(), from, seen)
+	case *types.Map:
+		if name := inaccessibleType(t.Key(), from, seen); name != "" {
+			return name
+		}
+		return inaccessibleType(t.Elem(), from, seen)
+	case *types.Signature:
+		for _, tuple := range []*types.Tuple{t.Params(), t.Results()} {
+			for i := 0; i < tuple.Len(); i++ {
+				if name := inaccessibleType(tuple.At(i).Type(), from, seen); name != "" {
+					return name
+				}
+			}
+		}
+	case *types.Struct:
+		for i := 0; i < t.NumFields(); i++ {
+			if name := inaccessibleType(t.Field(i).Type(), from, seen); name != "" {
+				return name
+			}
+		}
+	case *types.Interface:
+		for i := 0; i < t.NumExplicitMethods(); i++ {
+			if name := inaccessibleType(t.ExplicitMethod(i).Type(), from, seen); name != "" {
+				return name
+			}
+		}
+		for i := 0; i < t.NumEmbeddeds(); i++ {
+			if name := inaccessibleType(t.EmbeddedType(i), from, seen); name != "" {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+// inaccessibleName qualified name of the type name which may not be referenced from the package, empty if it may
+func inaccessibleName(object *types.TypeName, from string) string {
+	if object.Pkg() == nil || object.Pkg().Path() == from {
+		return ""
+	}
+	if !object.Exported() || !importable(object.Pkg().Path(), from) {
+		return fmt.Sprintf("%s.%s", object.Pkg().Path(), object.Name())
+	}
+	return ""
+}
@@ -0,0 +1,14 @@
+//go:build go1.22
+
+package generator
+
+import "go/types"
+
+// aliasOf type name of the alias and the aliased type, type checker of go1.22+ may keep aliases in the signatures
+func aliasOf(typeOf types.Type) (*types.TypeName, types.Type, bool) {
+	alias, ok := typeOf.(*types.Alias)
+	if !ok {
+		return nil, typeOf, false
+	}
+	return alias.Obj(), types.Unalias(alias), true
+}
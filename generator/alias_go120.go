@@ -0,0 +1,10 @@
+//go:build !go1.22
+
+package generator
+
+import "go/types"
+
+// aliasOf aliases are resolved by the type checker before go1.22, signatures refer to the aliased types only
+func aliasOf(typeOf types.Type) (*types.TypeName, types.Type, bool) {
+	return nil, typeOf, false
+}
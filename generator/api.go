@@ -0,0 +1,58 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"github.com/dave/jennifer/jen"
+	"go/types"
+	"strings"
+)
+
+// apiMethods method set of the target, ambiguous selectors of the embedded members are not in it,
+// so the facade may proxy them by the explicit path, but the interface may not declare them
+func apiMethods(ctx context.Context, run *run, cfg Config) (map[string]bool, error) {
+	pkg, err := loadRecord(ctx, run, cfg)
+	if err != nil {
+		return nil, err
+	}
+	target, ok := pkg.Scope().Lookup(cfg.Target).(*types.TypeName)
+	if !ok {
+		return nil, fmt.Errorf("%s: %s %w", cfg.Package, cfg.Target, NotFoundError)
+	}
+	receiver := target.Type()
+	if !types.IsInterface(receiver) {
+		receiver = types.NewPointer(receiver) //singleton is the pointer
+	}
+	methods := map[string]bool{}
+	set := types.NewMethodSet(receiver)
+	for i := 0; i < set.Len(); i++ {
+		methods[set.At(i).Obj().Name()] = true
+	}
+	return methods, nil
+}
+
+// glueAPI declares <Target>API interface with the method set of the facade
+// and compile time assertion that singleton satisfies it
+func glueAPI(output *jen.File, content []*wrappedFunctionDeclaration, varDecl *variableDecl, methods map[string]bool, cfg Config) {
+	name := apiName(cfg)
+
+	output.Line()
+	output.Comment(fmt.Sprintf("%s describes functions of the %s facade", name, strings.TrimSpace(cfg.Comment)))
+	output.Type().Id(name).InterfaceFunc(func(group *jen.Group) {
+		declared := map[string]bool{}
+		for _, fn := range content {
+			if fn.IsField || fn.origin == nil || declared[fn.Method] || !methods[fn.Method] {
+				continue //fields and ambiguous selectors are not part of the method set, renamed functions may call the same method
+			}
+			declared[fn.Method] = true
+			//shift (remove) func keyword from func abc()
+			group.Add(shiftStatement(fn.origin.buildFunction(fn.Method, fn.Params, fn.Results, nil, false)))
+		}
+	})
+	output.Line()
+	output.Var().Id("_").Id(name).Op("=").Add(varDecl.Reference())
+}
+
+func apiName(cfg Config) string {
+	return cfg.Target + "API"
+}
@@ -0,0 +1,125 @@
+package generator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// fingerprintRecord cached state of the inputs of the generated file
+type fingerprintRecord struct {
+	Dirs        []string `json:"dirs"`
+	Fingerprint string   `json:"fingerprint"`
+}
+
+// cacheable generation writes the file, fingerprint is not used for the other outputs and custom file selection,
+// explanation needs the walk
+func cacheable(run *run, cfg Config) bool {
+	return run.writer == nil && run.filter == nil && cfg.Write && !cfg.Force && !cfg.Check && !cfg.Diff && !cfg.Explain
+}
+
+// upToDate the file is generated from the same inputs with the same config
+func upToDate(cfg Config) bool {
+	path, err := cachePath(cfg)
+	if err != nil {
+		return false //output is not resolved, generation reports it
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	record := fingerprintRecord{}
+	if err = json.Unmarshal(content, &record); err != nil {
+		return false
+	}
+	current, err := fingerprint(cfg, record.Dirs)
+	return err == nil && current == record.Fingerprint
+}
+
+// storeFingerprint remembers inputs of the written file, dirs are read during the generation
+func storeFingerprint(cfg Config, dirs []string) {
+	record := fingerprintRecord{Dirs: dirs}
+	var err error
+	if record.Fingerprint, err = fingerprint(cfg, record.Dirs); err != nil {
+		info("fingerprint is not stored:", err)
+		return
+	}
+	content, _ := json.Marshal(record)
+	path, err := cachePath(cfg)
+	if err == nil {
+		err = os.MkdirAll(filepath.Dir(path), 0755)
+	}
+	if err == nil {
+		err = os.WriteFile(path, content, 0644)
+	}
+	if err != nil {
+		info("fingerprint is not stored:", err)
+	}
+}
+
+// fingerprint of the config, every go file of the directories and the generated file itself
+func fingerprint(cfg Config, dirs []string) (string, error) {
+	output, err := outputPath(cfg)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.New()
+	cfg.Force, cfg.Jobs, cfg.Explain = false, 0, false //do not change the output
+	if err := json.NewEncoder(hash).Encode(struct {
+		Version string
+		Config
+	}{Version, cfg}); err != nil {
+		return "", err
+	}
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return "", err
+		}
+		for _, entry := range entries {
+			path := filepath.Join(dir, entry.Name())
+			if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".go") || path == output {
+				continue
+			}
+			if err = hashFile(hash, path); err != nil {
+				return "", err
+			}
+		}
+	}
+	if err := hashFile(hash, output); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func hashFile(hash io.Writer, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	_, _ = io.WriteString(hash, path+"\n")
+	_, err = io.Copy(hash, file)
+	return err
+}
+
+// cachePath location of the fingerprint of the generated file
+func cachePath(cfg Config) (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	output, err := outputPath(cfg)
+	if err != nil {
+		return "", err
+	}
+	if abs, err := filepath.Abs(output); err == nil {
+		output = abs
+	}
+	name := sha256.Sum256([]byte(output))
+	return filepath.Join(dir, "gosingl", hex.EncodeToString(name[:])+".json"), nil
+}
@@ -0,0 +1,112 @@
+package generator
+
+import (
+	"fmt"
+	"go/build"
+	"strings"
+)
+
+// what embedded member of the stopped package contributes, see Config.Leaf
+const (
+	LeafMethods = "methods" //methods declared by the member itself, its embedded members are not walked
+	LeafNone    = "none"    //nothing, member is skipped
+)
+
+// package patterns of the rules, other patterns are import paths, "path/..." includes subpackages
+const (
+	StdPattern    = "std"    //packages of the standard library
+	ModulePattern = "module" //packages of the module of the target
+)
+
+// descentRules decide which embedded members are walked, --deep limits the depth only
+type descentRules struct {
+	descend, stopAt []string
+	leaf            string
+	module          string //module path of the target, if one of the patterns needs it
+}
+
+func newDescentRules(cfg Config) (*descentRules, error) {
+	rules := &descentRules{
+		descend: splitPatterns(cfg.Descend),
+		stopAt:  splitPatterns(cfg.StopAt),
+		leaf:    cfg.Leaf,
+	}
+	if rules.leaf == "" {
+		rules.leaf = LeafMethods
+	}
+	if rules.leaf != LeafMethods && rules.leaf != LeafNone {
+		return nil, fmt.Errorf("unknown leaf %s, %s or %s is expected", cfg.Leaf, LeafMethods, LeafNone)
+	}
+	for _, pattern := range append(append([]string{}, rules.descend...), rules.stopAt...) {
+		if pattern != ModulePattern || rules.module != "" {
+			continue
+		}
+		p, err := build.Default.Import(cfg.Package, ".", build.FindOnly)
+		if err != nil {
+			return nil, err
+		}
+		if _, rules.module, err = moduleRoot(p.Dir); err != nil {
+			return nil, fmt.Errorf("pattern %s: %w", ModulePattern, err)
+		}
+	}
+	return rules, nil
+}
+
+// stopped embedded members of the package are leaves, rule is the pattern which stops the walk
+func (r *descentRules) stopped(pkg string) (rule string, ok bool) {
+	for _, pattern := range r.stopAt {
+		if r.match(pattern, pkg) {
+			return "--stop-at " + pattern, true
+		}
+	}
+	if len(r.descend) == 0 {
+		return "", false
+	}
+	for _, pattern := range r.descend {
+		if r.match(pattern, pkg) {
+			return "", false
+		}
+	}
+	return "--descend " + strings.Join(r.descend, ","), true
+}
+
+func (r *descentRules) match(pattern, pkg string) bool {
+	switch {
+	case pattern == StdPattern:
+		p, err := build.Default.Import(pkg, ".", build.FindOnly)
+		return err == nil && p.Goroot
+	case pattern == ModulePattern:
+		return pkg == r.module || strings.HasPrefix(pkg, r.module+"/")
+	case strings.HasSuffix(pattern, "/..."):
+		root := strings.TrimSuffix(pattern, "/...")
+		return pkg == root || strings.HasPrefix(pkg, root+"/")
+	}
+	return pkg == pattern
+}
+
+func (r *descentRules) String() string {
+	rules := make([]string, 0, 3)
+	if len(r.descend) > 0 {
+		rules = append(rules, "descend into "+strings.Join(r.descend, ","))
+	}
+	if len(r.stopAt) > 0 {
+		rules = append(rules, "stop at "+strings.Join(r.stopAt, ","))
+	}
+	if len(rules) == 0 {
+		return "descend into every package"
+	}
+	if r.leaf == LeafNone {
+		return strings.Join(append(rules, "members of the stopped packages are skipped"), ", ")
+	}
+	return strings.Join(append(rules, "members of the stopped packages contribute their own methods"), ", ")
+}
+
+func splitPatterns(patterns string) []string {
+	var result []string
+	for _, pattern := range strings.Split(patterns, ",") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			result = append(result, pattern)
+		}
+	}
+	return result
+}
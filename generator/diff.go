@@ -0,0 +1,159 @@
+package generator
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+var StaleError = errors.New("generated file is stale")
+
+// diffContext number of the unchanged lines around the change
+const diffContext = 3
+
+type diffLine struct {
+	kind byte //' ' unchanged, '-' removed, '+' added
+	text string
+}
+
+// compareOutput prints unified diff between the file which would be written and rendered code,
+// in check mode difference is an error
+func compareOutput(run *run, cfg Config, rendered []byte) error {
+	path, err := outputPath(cfg)
+	if err != nil {
+		return err
+	}
+	previous, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if bytes.Equal(previous, rendered) {
+		return nil
+	}
+
+	writer := run.writer
+	if writer == nil {
+		writer = os.Stdout
+	}
+	if _, err = io.WriteString(writer, unifiedDiff(path, path, previous, rendered)); err != nil {
+		return err
+	}
+	if cfg.Check {
+		return fmt.Errorf("%w: %s", StaleError, path)
+	}
+	return nil
+}
+
+// unifiedDiff of the two texts, empty if they are equal
+func unifiedDiff(fromName, toName string, from, to []byte) string {
+	lines := diffLines(splitLines(from), splitLines(to))
+
+	//position of every line in both texts
+	fromPos, toPos := make([]int, len(lines)+1), make([]int, len(lines)+1)
+	for i, line := range lines {
+		fromPos[i+1], toPos[i+1] = fromPos[i], toPos[i]
+		if line.kind != '+' {
+			fromPos[i+1]++
+		}
+		if line.kind != '-' {
+			toPos[i+1]++
+		}
+	}
+
+	out := &strings.Builder{}
+	for i := 0; i < len(lines); {
+		if lines[i].kind == ' ' {
+			i++
+			continue
+		}
+		if out.Len() == 0 {
+			fmt.Fprintf(out, "--- %s\n+++ %s\n", fromName, toName)
+		}
+		//hunk lasts until the changes are separated by more than two contexts
+		last := i
+		for j := i; j < len(lines) && j-last <= 2*diffContext; j++ {
+			if lines[j].kind != ' ' {
+				last = j
+			}
+		}
+		start, stop := i-diffContext, last+diffContext+1
+		if start < 0 {
+			start = 0
+		}
+		if stop > len(lines) {
+			stop = len(lines)
+		}
+		fmt.Fprintf(out, "@@ -%s +%s @@\n",
+			hunkRange(fromPos[start], fromPos[stop]-fromPos[start]),
+			hunkRange(toPos[start], toPos[stop]-toPos[start]),
+		)
+		for _, line := range lines[start:stop] {
+			fmt.Fprintf(out, "%c%s\n", line.kind, line.text)
+		}
+		i = stop
+	}
+	return out.String()
+}
+
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+// diffLines builds edit script via longest common subsequence
+func diffLines(from, to []string) []diffLine {
+	common := make([][]int, len(from)+1)
+	for i := range common {
+		common[i] = make([]int, len(to)+1)
+	}
+	for i := len(from) - 1; i >= 0; i-- {
+		for j := len(to) - 1; j >= 0; j-- {
+			if from[i] == to[j] {
+				common[i][j] = common[i+1][j+1] + 1
+			} else if common[i+1][j] >= common[i][j+1] {
+				common[i][j] = common[i+1][j]
+			} else {
+				common[i][j] = common[i][j+1]
+			}
+		}
+	}
+
+	result := make([]diffLine, 0, len(from)+len(to))
+	i, j := 0, 0
+	for i < len(from) && j < len(to) {
+		switch {
+		case from[i] == to[j]:
+			result = append(result, diffLine{' ', from[i]})
+			i++
+			j++
+		case common[i+1][j] >= common[i][j+1]:
+			result = append(result, diffLine{'-', from[i]})
+			i++
+		default:
+			result = append(result, diffLine{'+', to[j]})
+			j++
+		}
+	}
+	for ; i < len(from); i++ {
+		result = append(result, diffLine{'-', from[i]})
+	}
+	for ; j < len(to); j++ {
+		result = append(result, diffLine{'+', to[j]})
+	}
+	return result
+}
+
+func splitLines(text []byte) []string {
+	if len(text) == 0 {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(string(text), "\n"), "\n")
+}
@@ -0,0 +1,331 @@
+package generator
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// AnnotationPrefix marks the type which facade is generated, options follow as key=value
+const AnnotationPrefix = "//gosingl:generate"
+
+var AnnotationError = errors.New("malformed annotation")
+
+// annotation one generation declared above the type
+type annotation struct {
+	Config
+	position token.Position
+}
+
+// annotationOptions setters of the config by the annotation key, keys mirror the flags
+var annotationOptions = map[string]func(cfg *Config, value string) error{
+	"variable":      func(cfg *Config, value string) error { cfg.Variable = value; return nil },
+	"comment":       func(cfg *Config, value string) error { cfg.Comment = value; return nil },
+	"suffix":        func(cfg *Config, value string) error { cfg.Suffix = value; return nil },
+	"filepath":      func(cfg *Config, value string) error { cfg.Path = value; return nil },
+	"mode":          func(cfg *Config, value string) error { cfg.Mode = value; return nil },
+	"include":       func(cfg *Config, value string) error { cfg.Include = value; return nil },
+	"exclude":       func(cfg *Config, value string) error { cfg.Exclude = value; return nil },
+	"out-pkg":       func(cfg *Config, value string) error { cfg.OutPackage = value; return nil },
+	"include-files": func(cfg *Config, value string) error { cfg.IncludeFiles = value; return nil },
+	"exclude-files": func(cfg *Config, value string) error { cfg.ExcludeFiles = value; return nil },
+	"descend":       func(cfg *Config, value string) error { cfg.Descend = value; return nil },
+	"stop-at":       func(cfg *Config, value string) error { cfg.StopAt = value; return nil },
+	"leaf":          func(cfg *Config, value string) error { cfg.Leaf = value; return nil },
+	"include-from":  func(cfg *Config, value string) error { cfg.IncludeFrom = value; return nil },
+	"exclude-from":  func(cfg *Config, value string) error { cfg.ExcludeFrom = value; return nil },
+	"receiver":      func(cfg *Config, value string) error { cfg.Receiver = value; return nil },
+	"via":           func(cfg *Config, value string) error { cfg.Via = value; return nil },
+	"name-prefix":   func(cfg *Config, value string) error { cfg.NamePrefix = value; return nil },
+	"name-suffix":   func(cfg *Config, value string) error { cfg.NameSuffix = value; return nil },
+	"rename":        func(cfg *Config, value string) error { cfg.Rename = value; return nil },
+	"name-template": func(cfg *Config, value string) error { cfg.NameTemplate = value; return nil },
+	"deep": func(cfg *Config, value string) (err error) {
+		cfg.Deep, err = strconv.Atoi(value)
+		return err
+	},
+	"api": func(cfg *Config, value string) (err error) {
+		cfg.API, err = strconv.ParseBool(value)
+		return err
+	},
+	"strict": func(cfg *Config, value string) (err error) {
+		cfg.Strict, err = strconv.ParseBool(value)
+		return err
+	},
+	"verify": func(cfg *Config, value string) (err error) {
+		cfg.Verify, err = strconv.ParseBool(value)
+		return err
+	},
+}
+
+// discoverAnnotations finds annotated types of the packages, pattern is a directory, "dir/..." includes subdirectories.
+// malformed annotations are reported with the position and skipped
+func discoverAnnotations(pattern string) ([]annotation, []error) {
+	var (
+		result []annotation
+		errs   []error
+	)
+	dirs, err := patternDirs(pattern)
+	if err != nil {
+		return nil, []error{err}
+	}
+	for _, dir := range dirs {
+		fileSet := token.NewFileSet()
+		packages, err := parser.ParseDir(fileSet, dir, func(info fs.FileInfo) bool {
+			return !strings.HasSuffix(info.Name(), "_test.go") && !isOutput(filepath.Join(dir, info.Name()))
+		}, parser.ParseComments)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		var files []string
+		for _, pkg := range packages {
+			for path := range pkg.Files {
+				files = append(files, path)
+			}
+		}
+		if len(files) == 0 {
+			continue
+		}
+		sort.Strings(files)
+		pkgPath, err := importPathOf(dir)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		for _, path := range files {
+			file := parsedFile(packages, path)
+			for _, decl := range file.Decls {
+				genDecl, ok := decl.(*ast.GenDecl)
+				if !ok || genDecl.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range genDecl.Specs {
+					typeSpec := spec.(*ast.TypeSpec)
+					doc := typeSpec.Doc
+					if doc == nil && len(genDecl.Specs) == 1 {
+						doc = genDecl.Doc
+					}
+					if doc == nil {
+						continue
+					}
+					for _, comment := range doc.List {
+						if comment.Text != AnnotationPrefix && !strings.HasPrefix(comment.Text, AnnotationPrefix+" ") {
+							continue
+						}
+						position := fileSet.Position(comment.Pos())
+						cfg, err := parseAnnotation(strings.TrimPrefix(comment.Text, AnnotationPrefix))
+						if err != nil {
+							errs = append(errs, fmt.Errorf("%s: %w", position, err))
+							continue
+						}
+						cfg.Package, cfg.Target = pkgPath, typeSpec.Name.Name
+						if cfg.Path != "" && !filepath.IsAbs(cfg.Path) {
+							cfg.Path = filepath.Join(dir, cfg.Path)
+						}
+						result = append(result, annotation{Config: cfg, position: position})
+					}
+				}
+			}
+		}
+	}
+	return result, errs
+}
+
+func parsedFile(packages map[string]*ast.Package, path string) *ast.File {
+	for _, pkg := range packages {
+		if file, ok := pkg.Files[path]; ok {
+			return file
+		}
+	}
+	return nil
+}
+
+// parseAnnotation builds config from the options of the annotation: key=value, key="quoted value" or bool key
+func parseAnnotation(options string) (Config, error) {
+	cfg := Config{Comment: DefaultComment, Write: true}
+	tokens, err := splitOptions(options)
+	if err != nil {
+		return cfg, err
+	}
+	for _, option := range tokens {
+		key, value, found := strings.Cut(option, "=")
+		setter, ok := annotationOptions[key]
+		if !ok {
+			return cfg, fmt.Errorf("%w: unknown key %q", AnnotationError, key)
+		}
+		if !found {
+			value = "true" //bool key
+		}
+		if err = setter(&cfg, value); err != nil {
+			return cfg, fmt.Errorf("%w: key %s: %s", AnnotationError, key, err)
+		}
+	}
+	return cfg, nil
+}
+
+// splitOptions splits by spaces, value may be quoted in order to keep spaces: variable="g[int, bool]"
+func splitOptions(options string) ([]string, error) {
+	var (
+		result  []string
+		current strings.Builder
+	)
+	for i := 0; i < len(options); i++ {
+		switch c := options[i]; {
+		case c == ' ' || c == '\t':
+			if current.Len() > 0 {
+				result = append(result, current.String())
+				current.Reset()
+			}
+		case c == '"':
+			quoted, err := strconv.QuotedPrefix(options[i:])
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s", AnnotationError, err)
+			}
+			unquoted, _ := strconv.Unquote(quoted)
+			current.WriteString(unquoted)
+			i += len(quoted) - 1
+		default:
+			current.WriteByte(c)
+		}
+	}
+	if current.Len() > 0 {
+		result = append(result, current.String())
+	}
+	return result, nil
+}
+
+// patternDirs directories of the pattern, "dir/..." includes subdirectories
+func patternDirs(pattern string) ([]string, error) {
+	root, recursive := pattern, false
+	if strings.HasSuffix(pattern, "...") {
+		root, recursive = strings.TrimSuffix(strings.TrimSuffix(pattern, "..."), "/"), true
+	}
+	if root == "" {
+		root = "."
+	}
+	var dirs []string
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir() {
+			return nil
+		}
+		if path != root && (!recursive || entry.Name() == "vendor" || entry.Name() == "testdata" || strings.HasPrefix(entry.Name(), ".")) {
+			return filepath.SkipDir
+		}
+		dirs = append(dirs, path)
+		return nil
+	})
+	return dirs, err
+}
+
+// importPathOf import path of the directory inside of the module
+func importPathOf(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	root, module, err := moduleRoot(dir)
+	if err != nil {
+		return "", err
+	}
+	rel, err := filepath.Rel(root, dir)
+	if err != nil {
+		return "", err
+	}
+	if rel == "." {
+		return module, nil
+	}
+	return module + "/" + filepath.ToSlash(rel), nil
+}
+
+// moduleRoot directory of the go.mod of the directory and the module path
+func moduleRoot(dir string) (root, module string, err error) {
+	for root = dir; ; {
+		if module, err = modulePath(filepath.Join(root, "go.mod")); err == nil {
+			return root, module, nil
+		}
+		parent := filepath.Dir(root)
+		if parent == root {
+			return "", "", fmt.Errorf("%s: module %w", dir, NotFoundError)
+		}
+		root = parent
+	}
+}
+
+func modulePath(goMod string) (string, error) {
+	file, err := os.Open(goMod)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); strings.HasPrefix(line, "module ") {
+			return strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "module")), `"`), nil
+		}
+	}
+	return "", fmt.Errorf("%s: module directive %w", goMod, NotFoundError)
+}
+
+// GenerateAnnotated generates every annotated type of the pattern
+func (g *Generator) GenerateAnnotated(ctx context.Context, pattern string, options Config) error {
+	annotations, errs := discoverAnnotations(pattern)
+	configs, origins := make([]Config, 0, len(annotations)), make([]string, 0, len(annotations))
+	for _, found := range annotations {
+		configs = append(configs, found.Config)
+		origins = append(origins, found.position.String())
+	}
+	if err := g.generateTargets(ctx, configs, origins, options); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// GoGenerateTarget infers PKG and TARGET of the //go:generate directive: package is the import path of the directory,
+// target is the first type declared after the directive. go generate sets $GOFILE, $GOLINE and $GOPACKAGE
+func GoGenerateTarget(dir string) (pkg, target string, err error) {
+	goFile, goLine, goPackage := os.Getenv("GOFILE"), os.Getenv("GOLINE"), os.Getenv("GOPACKAGE")
+	if goFile == "" || goLine == "" {
+		return "", "", fmt.Errorf("$GOFILE and $GOLINE %w, PKG and TARGET are required", NotFoundError)
+	}
+	line, err := strconv.Atoi(goLine)
+	if err != nil {
+		return "", "", fmt.Errorf("$GOLINE: %w", err)
+	}
+	path := filepath.Join(dir, goFile)
+	fileSet := token.NewFileSet()
+	file, err := parser.ParseFile(fileSet, path, nil, parser.SkipObjectResolution)
+	if err != nil {
+		return "", "", err
+	}
+	if goPackage != "" && file.Name.Name != goPackage {
+		return "", "", fmt.Errorf("%s: package %s, $GOPACKAGE is %s", path, file.Name.Name, goPackage)
+	}
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			typeSpec := spec.(*ast.TypeSpec)
+			if fileSet.Position(typeSpec.Pos()).Line > line {
+				pkg, err = importPathOf(dir)
+				return pkg, typeSpec.Name.Name, err
+			}
+		}
+	}
+	return "", "", fmt.Errorf("%s:%d: type after the directive %w", path, line, NotFoundError)
+}
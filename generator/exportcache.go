@@ -0,0 +1,157 @@
+package generator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"go/build"
+	"go/importer"
+	"go/token"
+	"go/types"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// exportTTL entries which are not used for so long are removed
+const exportTTL = 30 * 24 * time.Hour
+
+// exportImporter imports the standard library from the export data of the go tool. export data is copied into the
+// cache dir, next runs do not spawn the go tool for the same package of the same go version.
+// packages outside the standard library are not imported, like importer.Default does in the module mode,
+// the type check of the declarations does not need them
+type exportImporter struct {
+	dir      string
+	importer types.ImporterFrom
+	hits     int //lookups served by the cache dir
+	mux      sync.Mutex
+}
+
+// newExportImporter shared by the packages of the run, imported packages are reused
+func newExportImporter(dir string) *exportImporter {
+	i := &exportImporter{dir: dir}
+	i.importer = importer.ForCompiler(token.NewFileSet(), "gc", i.lookup).(types.ImporterFrom)
+	return i
+}
+
+// exportDir default location of the export data
+func exportDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "gosingl", "export")
+}
+
+func (i *exportImporter) Import(path string) (*types.Package, error) {
+	return i.ImportFrom(path, "", 0)
+}
+
+// ImportFrom packages are checked concurrently, importer is not safe for it
+func (i *exportImporter) ImportFrom(path, dir string, mode types.ImportMode) (*types.Package, error) {
+	i.mux.Lock()
+	defer i.mux.Unlock()
+	return i.importer.ImportFrom(path, dir, mode)
+}
+
+// lookup export data of the package, the cache dir first
+func (i *exportImporter) lookup(path string) (io.ReadCloser, error) {
+	pkg, err := build.Default.Import(path, "", build.FindOnly)
+	if err != nil {
+		return nil, err
+	}
+	if !pkg.Goroot {
+		return nil, fmt.Errorf("can't find import: %q, only the standard library is imported", path)
+	}
+	key, err := exportKey(path, pkg.Dir)
+	if err != nil {
+		return nil, err
+	}
+	entry := filepath.Join(i.dir, key)
+	if file, err := os.Open(entry); err == nil {
+		now := time.Now()
+		_ = os.Chtimes(entry, now, now) //still in use
+		i.hits++
+		return file, nil
+	}
+	if err = i.store(path, entry); err != nil {
+		return nil, err
+	}
+	return os.Open(entry)
+}
+
+// store export data of the go tool into the cache dir, outdated entries are evicted on the way
+func (i *exportImporter) store(path, entry string) error {
+	out, err := exec.Command("go", "list", "-export", "-f", "{{.Export}}", path).Output()
+	if err != nil {
+		return fmt.Errorf("can't find export data of %s: %w", path, err)
+	}
+	export := strings.TrimSpace(string(out))
+	if export == "" {
+		return fmt.Errorf("can't find export data of %s", path)
+	}
+	content, err := os.ReadFile(export)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(i.dir, 0755); err != nil {
+		return err
+	}
+	i.evict()
+	temp, err := os.CreateTemp(i.dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	_, err = temp.Write(content)
+	if closeErr := temp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(temp.Name(), entry) //concurrent processes write the same content
+	}
+	if err != nil {
+		_ = os.Remove(temp.Name())
+	}
+	return err
+}
+
+// evict entries which are not used for exportTTL
+func (i *exportImporter) evict() {
+	entries, err := os.ReadDir(i.dir)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		stat, err := entry.Info()
+		if err == nil && time.Since(stat.ModTime()) > exportTTL {
+			_ = os.Remove(filepath.Join(i.dir, entry.Name()))
+		}
+	}
+}
+
+// exportKey export data depends on the go version and the sources of the package
+func exportKey(path, dir string) (string, error) {
+	hash := sha256.New()
+	_, _ = io.WriteString(hash, Version+"\n"+runtime.Version()+"\n"+build.Default.GOOS+"/"+build.Default.GOARCH+"\n"+path+"\n")
+	if version, err := os.ReadFile(filepath.Join(build.Default.GOROOT, "VERSION")); err == nil {
+		_, _ = hash.Write(version) //go tool may differ from the one gosingl is built with
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".go") || strings.HasSuffix(entry.Name(), "_test.go") {
+			continue
+		}
+		if err = hashFile(hash, filepath.Join(dir, entry.Name())); err != nil {
+			return "", err
+		}
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,110 @@
+package generator
+
+import (
+	"fmt"
+	"github.com/dave/jennifer/jen"
+	"strings"
+)
+
+// glueFake declares Fake<Target> structure which implements every proxied method,
+// records calls and delegates it to the <Method>Func field if it set.
+// fake stands in for the target, so names of the facade functions are not used
+func glueFake(output *jen.File, content []*wrappedFunctionDeclaration, cfg Config) {
+	name := fakeName(cfg)
+	faked := make([]*wrappedFunctionDeclaration, 0, len(content))
+	declared := map[string]bool{}
+	for _, fn := range content {
+		if fn.origin == nil || declared[fn.Method] {
+			continue //renamed functions may call the same method
+		}
+		declared[fn.Method] = true
+		faked = append(faked, fn)
+	}
+
+	output.Comment(fmt.Sprintf("%s is a fake of the %s facade, it records calls and delegates them to <Method>Func fields", name, strings.TrimSpace(cfg.Comment)))
+	output.Type().Id(name).StructFunc(func(group *jen.Group) {
+		group.Id("mut").Qual("sync", "Mutex")
+		for _, fn := range faked {
+			params := namedParams(fn.Params, fn.origin.namer(), "")
+			group.Line()
+			group.Id(fn.Method + "Func").Add(fn.origin.buildFunction("", fn.Params, fn.Results, fn.origin.namer(), false))
+			group.Id(fn.Method + "Calls").Index().StructFunc(func(group *jen.Group) {
+				for _, param := range params {
+					group.Id(param.Name).Add(fn.origin.buildValueType(param))
+				}
+			})
+		}
+	})
+
+	for _, fn := range faked {
+		output.Line()
+		glueFakeMethod(output, name, fn)
+		output.Line()
+		output.Func().Params(jen.Id("fake").Op("*").Id(name)).Id(fn.Method+"CallCount").Params().Int().Block(
+			jen.Id("fake").Dot("mut").Dot("Lock").Call(),
+			jen.Defer().Id("fake").Dot("mut").Dot("Unlock").Call(),
+			jen.Return(jen.Len(jen.Id("fake").Dot(fn.Method+"Calls"))),
+		)
+	}
+}
+
+func glueFakeMethod(output *jen.File, name string, fn *wrappedFunctionDeclaration) {
+	g := fn.origin
+	namer := g.namer()
+	params := namedParams(fn.Params, namer, "")
+	results := namedParams(fn.Results, namer, "r")
+
+	signature := jen.Id(fn.Method).ParamsFunc(func(group *jen.Group) {
+		for _, param := range params {
+			group.Id(param.Name).Add(g.recursBuildParam(param.Field.Type, &jen.Statement{}))
+		}
+	})
+	if len(results) > 0 {
+		//results are always named, so zero values may be returned by bare return
+		signature.ParamsFunc(func(group *jen.Group) {
+			for _, result := range results {
+				group.Id(result.Name).Add(g.recursBuildParam(result.Field.Type, &jen.Statement{}))
+			}
+		})
+	}
+
+	receiver, stub := freeName(namer, "fake", params, results), freeName(namer, "stub", params, results)
+	output.Func().Params(jen.Id(receiver).Op("*").Id(name)).Add(signature).BlockFunc(func(group *jen.Group) {
+		group.Id(receiver).Dot("mut").Dot("Lock").Call()
+		group.Id(receiver).Dot(fn.Method+"Calls").Op("=").Append(
+			jen.Id(receiver).Dot(fn.Method+"Calls"),
+			jen.StructFunc(func(group *jen.Group) {
+				for _, param := range params {
+					group.Id(param.Name).Add(g.buildValueType(param))
+				}
+			}).ValuesFunc(func(group *jen.Group) {
+				for _, param := range params {
+					group.Id(param.Name)
+				}
+			}),
+		)
+		group.Id(stub).Op(":=").Id(receiver).Dot(fn.Method + "Func")
+		group.Id(receiver).Dot("mut").Dot("Unlock").Call()
+		group.If(jen.Id(stub).Op("==").Nil()).BlockFunc(func(group *jen.Group) {
+			group.Return()
+		})
+		call := jen.Id(stub).CallFunc(func(group *jen.Group) {
+			for _, param := range params {
+				if param.IsVariadic() {
+					group.Id(param.Name).Op("...")
+				} else {
+					group.Id(param.Name)
+				}
+			}
+		})
+		if len(results) > 0 {
+			group.Return(call)
+		} else {
+			group.Add(call)
+		}
+	})
+}
+
+func fakeName(cfg Config) string {
+	return "Fake" + strings.ToUpper(cfg.Target[0:1]) + cfg.Target[1:]
+}
@@ -0,0 +1,84 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// receiver kinds of the methods, see Config.Receiver
+const (
+	ValueReceiver   = "value"
+	PointerReceiver = "pointer"
+)
+
+// functionFilter selects generated functions by the name, origin and receiver, it is applied before the checker,
+// so excluded functions are not compared with the others
+type functionFilter struct {
+	names                    *nameFilter
+	includeFrom, excludeFrom []string
+	receiver                 string
+}
+
+func newFunctionFilter(cfg Config) (*functionFilter, error) {
+	names, err := newNameFilter(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Receiver != "" && cfg.Receiver != ValueReceiver && cfg.Receiver != PointerReceiver {
+		return nil, fmt.Errorf("unknown receiver %s, %s or %s is expected", cfg.Receiver, ValueReceiver, PointerReceiver)
+	}
+	return &functionFilter{
+		names:       names,
+		includeFrom: splitPatterns(cfg.IncludeFrom),
+		excludeFrom: splitPatterns(cfg.ExcludeFrom),
+		receiver:    cfg.Receiver,
+	}, nil
+}
+
+// Apply keeps the selected functions, reason of the exclusion is explained
+func (f *functionFilter) Apply(run *run, cfg Config, content []*wrappedFunctionDeclaration) []*wrappedFunctionDeclaration {
+	selected := make([]*wrappedFunctionDeclaration, 0, len(content))
+	for _, fn := range content {
+		if reason := f.excluded(fn); reason != "" {
+			run.explain(cfg, "%s %s: excluded by %s", fn.Comment, fn.Name, reason)
+			continue
+		}
+		selected = append(selected, fn)
+	}
+	return selected
+}
+
+func (f *functionFilter) excluded(fn *wrappedFunctionDeclaration) string {
+	if !f.names.Match(fn.Method) {
+		return "--include/--exclude"
+	}
+	if len(f.includeFrom) > 0 && !f.from(f.includeFrom, fn) {
+		return "--include-from"
+	}
+	if len(f.excludeFrom) > 0 && f.from(f.excludeFrom, fn) {
+		return "--exclude-from"
+	}
+	//interface methods and function fields have no receiver
+	if fn.IsInterface || fn.IsField || f.receiver == "" {
+		return ""
+	}
+	if fn.PointerReceiver != (f.receiver == PointerReceiver) {
+		return "--receiver " + f.receiver
+	}
+	return ""
+}
+
+// from function is promoted through the origin: path of the embedded fields (tl2.SectionReader)
+// or the declaring type, qualified by the package name or not (io.SectionReader, tl2)
+func (f *functionFilter) from(origins []string, fn *wrappedFunctionDeclaration) bool {
+	path := "." + strings.Join(fn.CallPrefix, ".") + "."
+	for _, origin := range origins {
+		if len(fn.CallPrefix) > 0 && strings.Contains(path, "."+origin+".") {
+			return true
+		}
+		if origin == packageName(fn.Package)+"."+fn.Target || origin == fn.Target {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,305 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"github.com/dave/jennifer/jen"
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+var (
+	ForwardTargetError = errors.New("forwarding tests require interface target")
+	ForwardFacadeError = errors.New("forwarding tests require the facade")
+)
+
+// checkForwardFacade tests install stand-in into the variable and call the proxies by name,
+// so the facade must be generated into the output package before the tests
+func checkForwardFacade(run *run, cfg Config, content []*wrappedFunctionDeclaration, variable string) error {
+	path, files, _, err := collectFiles(outputPackage(cfg), []string{"_test.go"})
+	if err != nil {
+		return err
+	}
+	run.collect(path)
+	declared := map[string]bool{}
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				if decl.Recv == nil {
+					declared[decl.Name.Name] = true
+				}
+			case *ast.GenDecl:
+				if decl.Tok != token.VAR {
+					continue
+				}
+				for _, spec := range decl.Specs {
+					for _, name := range spec.(*ast.ValueSpec).Names {
+						declared[name.Name] = true
+					}
+				}
+			}
+		}
+	}
+	if !declared[variable] {
+		return fmt.Errorf("%s: %w, variable %s is not declared", outputPackage(cfg), ForwardFacadeError, variable)
+	}
+	for _, fn := range content {
+		if fn.origin != nil && !declared[fn.Name] {
+			return fmt.Errorf("%s: %w, function %s is not declared", outputPackage(cfg), ForwardFacadeError, fn.Name)
+		}
+	}
+	return nil
+}
+
+// glueForwardTest declares one test per proxy, every test installs recording stand-in as singleton
+// and checks that proxy forwards sentinel arguments and results as is
+func glueForwardTest(run *run, output *jen.File, content []*wrappedFunctionDeclaration, varDecl *variableDecl, cfg Config) error {
+	if !varDecl.IsInterface() {
+		return fmt.Errorf("%s: %s %w", cfg.Package, cfg.Target, ForwardTargetError)
+	}
+	if err := checkForwardFacade(run, cfg, content, varDecl.Variable); err != nil {
+		return err
+	}
+	for _, fn := range content {
+		if fn.origin == nil {
+			continue
+		}
+		output.Line()
+		glueForwardCase(output, fn, varDecl)
+	}
+	return nil
+}
+
+func glueForwardCase(output *jen.File, fn *wrappedFunctionDeclaration, varDecl *variableDecl) {
+	var (
+		g         = fn.origin
+		namer     = g.namer()
+		params    = namedParams(fn.Params, namer, "")
+		results   = namedParams(fn.Results, namer, "r")
+		signature = fn.signature() //sentinels of the unresolved types are zero values
+		stand     = "forward" + fn.Name + "Stand"
+		sentinel  = 0
+		//locals of the test must not shadow the proxy
+		receiver = freeName(namer, "stand", params)
+		standVar = freeName(namer, "stand", []namedParam{{Name: fn.Name}})
+		restore  = freeName(namer, "restore", []namedParam{{Name: fn.Name}})
+	)
+
+	//stand-in embeds target, so only tested method is implemented
+	output.Type().Id(stand).StructFunc(func(group *jen.Group) {
+		group.Add(varDecl.TargetType())
+		group.Id("in").Index().Any()
+		for i, result := range results {
+			group.Id(fmt.Sprintf("r%d", i)).Add(g.recursBuildParam(result.Field.Type, &jen.Statement{}))
+		}
+	})
+	output.Line()
+	output.Func().Params(jen.Id(receiver).Op("*").Id(stand)).Id(fn.Method).ParamsFunc(func(group *jen.Group) {
+		for _, param := range params {
+			group.Id(param.Name).Add(g.recursBuildParam(param.Field.Type, &jen.Statement{}))
+		}
+	}).ParamsFunc(func(group *jen.Group) {
+		for _, result := range results {
+			group.Add(g.recursBuildParam(result.Field.Type, &jen.Statement{}))
+		}
+	}).BlockFunc(func(group *jen.Group) {
+		group.Id(receiver).Dot("in").Op("=").Index().Any().ValuesFunc(func(group *jen.Group) {
+			for _, param := range params {
+				group.Id(param.Name)
+			}
+		})
+		if len(results) > 0 {
+			group.ReturnFunc(func(group *jen.Group) {
+				for i := range results {
+					group.Id(receiver).Dot(fmt.Sprintf("r%d", i))
+				}
+			})
+		}
+	})
+	output.Line()
+
+	//non-empty interface sentinel is a stub which embeds the interface, id makes it distinguishable
+	stub := func(iface *jen.Statement, sentinel int) *jen.Statement {
+		name := fmt.Sprintf("forward%sStub%d", fn.Name, sentinel)
+		output.Type().Id(name).Struct(iface, jen.Id("id").Int())
+		output.Line()
+		return jen.Id(name).Values(jen.Id("id").Op(":").Lit(sentinel))
+	}
+
+	output.Func().Id("TestForward" + fn.Name).Params(jen.Id("t").Op("*").Qual("testing", "T")).BlockFunc(func(group *jen.Group) {
+		group.Id(standVar).Op(":=").Op("&").Id(stand).ValuesFunc(func(values *jen.Group) {
+			for i, result := range results {
+				sentinel++
+				values.Id(fmt.Sprintf("r%d", i)).Op(":").Add(forwardSentinel(g, result.Field.Type, resultType(signature, i), sentinel, stub))
+			}
+		})
+		group.Id(restore).Op(":=").Id(varDecl.Variable)
+		group.Id(varDecl.Variable).Op("=").Id(standVar)
+		group.Defer().Func().Params().Block(jen.Id(varDecl.Variable).Op("=").Id(restore)).Call()
+		group.Line()
+
+		var (
+			args     []jen.Code
+			expected []jen.Code
+			identity []bool //pointer is compared by the address, not the pointee
+		)
+		for i, param := range params {
+			typeOf := paramType(signature, i)
+			if ellipsis, ok := param.Field.Type.(*ast.Ellipsis); ok {
+				//variadic parameter receives two sentinels and forwards them as slice
+				var elem types.Type
+				if slice, ok := typeOf.(*types.Slice); ok {
+					elem = slice.Elem()
+				}
+				variadic := make([]jen.Code, 0, 2)
+				for j := 0; j < 2; j++ {
+					sentinel++
+					arg := fmt.Sprintf("arg%d", sentinel)
+					group.Id(arg).Op(":=").Add(forwardSentinel(g, ellipsis.Elt, elem, sentinel, stub))
+					args = append(args, jen.Id(arg))
+					variadic = append(variadic, jen.Id(arg))
+				}
+				expected = append(expected, g.buildValueType(param).Values(variadic...))
+				identity = append(identity, false)
+				continue
+			}
+			sentinel++
+			arg := fmt.Sprintf("arg%d", sentinel)
+			group.Id(arg).Op(":=").Add(forwardSentinel(g, param.Field.Type, typeOf, sentinel, stub))
+			args = append(args, jen.Id(arg))
+			expected = append(expected, jen.Id(arg))
+			identity = append(identity, isPointer(typeOf))
+		}
+
+		call := jen.Id(fn.Name).Call(args...)
+		if len(results) > 0 {
+			got := make([]jen.Code, 0, len(results))
+			for i := range results {
+				got = append(got, jen.Id(fmt.Sprintf("got%d", i)))
+			}
+			group.List(got...).Op(":=").Add(call)
+		} else {
+			group.Add(call)
+		}
+		group.Line()
+
+		group.If(jen.Len(jen.Id(standVar).Dot("in")).Op("!=").Lit(len(expected))).Block(
+			jen.Id("t").Dot("Fatalf").Call(jen.Lit(fn.Name+" forwarded %v, expected %d arguments"), jen.Id(standVar).Dot("in"), jen.Lit(len(expected))),
+		)
+		for i := range expected {
+			in := jen.Id(standVar).Dot("in").Index(jen.Lit(i))
+			group.If(forwardDiffers(in, expected[i], identity[i])).Block(
+				jen.Id("t").Dot("Errorf").Call(jen.Lit(fmt.Sprintf("%s argument %d is %%v, expected %%v", fn.Name, i)), in.Clone(), expected[i]),
+			)
+		}
+		for i := range results {
+			got, want := jen.Id(fmt.Sprintf("got%d", i)), jen.Id(standVar).Dot(fmt.Sprintf("r%d", i))
+			group.If(forwardDiffers(got, want, isPointer(resultType(signature, i)))).Block(
+				jen.Id("t").Dot("Errorf").Call(jen.Lit(fmt.Sprintf("%s result %d is %%v, expected %%v", fn.Name, i)), got.Clone(), want.Clone()),
+			)
+		}
+	})
+}
+
+// forwardDiffers condition of the forwarded value which is not the same as expected
+func forwardDiffers(got, expected jen.Code, identity bool) *jen.Statement {
+	if identity {
+		return jen.Add(got).Op("!=").Add(expected)
+	}
+	return jen.Op("!").Qual("reflect", "DeepEqual").Call(got, expected)
+}
+
+// forwardSentinel build value of the type distinguishable from the other sentinels where it possible,
+// otherwise zero value of the type. Stub builds the sentinel of the non-empty interface
+func forwardSentinel(g *generator, expr ast.Expr, typeOf types.Type, sentinel int, stub func(iface *jen.Statement, sentinel int) *jen.Statement) *jen.Statement {
+	zero := jen.Op("*").New(g.recursBuildParam(expr, &jen.Statement{}))
+	if typeOf == nil {
+		return zero
+	}
+	if types.Identical(typeOf, types.Universe.Lookup("error").Type()) {
+		return jen.Qual("errors", "New").Call(jen.Lit(fmt.Sprintf("r%d", sentinel)))
+	}
+	switch underlying := typeOf.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case underlying.Info()&types.IsBoolean != 0:
+			return g.recursBuildParam(expr, &jen.Statement{}).Call(jen.Lit(sentinel%2 == 1))
+		case underlying.Info()&types.IsNumeric != 0:
+			return g.recursBuildParam(expr, &jen.Statement{}).Call(jen.Lit(sentinel))
+		case underlying.Info()&types.IsString != 0:
+			return g.recursBuildParam(expr, &jen.Statement{}).Call(jen.Lit(fmt.Sprintf("p%d", sentinel)))
+		}
+	case *types.Pointer:
+		if star, ok := expr.(*ast.StarExpr); ok {
+			//every pointer has own pointee, pointee holds the sentinel
+			elem := g.recursBuildParam(star.X, &jen.Statement{})
+			return jen.Op("&").Index().Add(elem).Values(forwardSentinel(g, star.X, underlying.Elem(), sentinel, stub)).Index(jen.Lit(0))
+		}
+	case *types.Slice:
+		return jen.Make(g.recursBuildParam(expr, &jen.Statement{}), jen.Lit(sentinel))
+	case *types.Chan:
+		return jen.Make(g.recursBuildParam(expr, &jen.Statement{}))
+	case *types.Struct:
+		for i := 0; i < underlying.NumFields(); i++ {
+			field := underlying.Field(i)
+			basic, ok := field.Type().Underlying().(*types.Basic)
+			if !field.Exported() || !ok {
+				continue
+			}
+			//untyped constant is assignable to the field of the named basic type
+			var value jen.Code
+			switch {
+			case basic.Info()&types.IsBoolean != 0:
+				value = jen.Lit(sentinel%2 == 1)
+			case basic.Info()&types.IsNumeric != 0:
+				value = jen.Lit(sentinel)
+			case basic.Info()&types.IsString != 0:
+				value = jen.Lit(fmt.Sprintf("p%d", sentinel))
+			default:
+				continue
+			}
+			return g.recursBuildParam(expr, &jen.Statement{}).Values(jen.Id(field.Name()).Op(":").Add(value))
+		}
+	case *types.Interface:
+		if _, ok := typeOf.(*types.TypeParam); ok {
+			break //constraint, not the value
+		}
+		if underlying.Empty() {
+			return g.recursBuildParam(expr, &jen.Statement{}).Call(jen.Lit(fmt.Sprintf("p%d", sentinel)))
+		}
+		switch expr.(type) {
+		case *ast.Ident, *ast.SelectorExpr: //named interface may be embedded
+			if stub != nil {
+				return stub(g.recursBuildParam(expr, &jen.Statement{}), sentinel)
+			}
+		}
+	}
+	return zero
+}
+
+// isPointer forwarded value of the type must be the same pointer
+func isPointer(typeOf types.Type) bool {
+	if typeOf == nil {
+		return false
+	}
+	_, ok := typeOf.Underlying().(*types.Pointer)
+	return ok
+}
+
+// paramType type of the i-th parameter, nil if signature is unknown
+func paramType(signature *types.Signature, i int) types.Type {
+	if signature == nil || i >= signature.Params().Len() {
+		return nil
+	}
+	return signature.Params().At(i).Type()
+}
+
+// resultType type of the i-th result, nil if signature is unknown
+func resultType(signature *types.Signature, i int) types.Type {
+	if signature == nil || i >= signature.Results().Len() {
+		return nil
+	}
+	return signature.Results().At(i).Type()
+}
@@ -0,0 +1,1721 @@
+package generator
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestMain fingerprints of the generations are stored in the temporary cache dir, not in the cache of the user
+func TestMain(m *testing.M) {
+	os.Exit(func() int {
+		dir, err := os.MkdirTemp("", "gosingl-cache")
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer os.RemoveAll(dir)
+		if os.Getenv("GOCACHE") == "" { //build cache of the go tool stays where it is
+			if userCache, err := os.UserCacheDir(); err == nil {
+				os.Setenv("GOCACHE", filepath.Join(userCache, "go-build"))
+			}
+		}
+		os.Setenv("XDG_CACHE_HOME", dir)
+		return m.Run()
+	}())
+}
+
+//regenerate golden files of the fixtures from their headers, use with caution: the result is what the tests expect.
+//test/composition and test/deep goldens differ from the current output, test/sources files are hand written, they are kept
+//go:generate go run .. regen ../test/annotated ../test/api ../test/arraySliceType ../test/arrayTarget ../test/callbackType ../test/empty ../test/forward ../test/generics ../test/interfaceType ../test/interfaceValidation ../test/leak ../test/mapTarget ../test/mapType ../test/outPackage/facade ../test/reexport ../test/rename ../test/rpc ../test/sectionReader ../test/sectionReader/testdata ../test/sliceTarget ../test/split ../test/via ../test/via/testdata
+
+func TestInterface(t *testing.T) {
+	ctx := context.Background()
+	cfg := Config{
+		Package:  "github.com/alh1m1k/gosingl/test/interfaceType",
+		Target:   "interfaceType",
+		Variable: "Instance",
+		Comment:  "Code generated by <git repo>. DO NOT EDIT.",
+		Write:    true,
+		Deep:     0,
+	}
+
+	b := &bytes.Buffer{}
+	if _, err := New(WithWriter(b)).Generate(ctx, cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := os.ReadFile("../test/interfaceType/interfaceType_singleton.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.String() != string(result) {
+		t.Fatalf("result is not the same as expected : %s\n %s", diff(b.String(), string(result), 10), b.String())
+	}
+
+}
+
+func TestMap(t *testing.T) {
+	ctx := context.Background()
+	cfg := Config{
+		Package:  "github.com/alh1m1k/gosingl/test/mapType",
+		Target:   "mapType",
+		Variable: "Instance",
+		Comment:  "Code generated by <git repo>. DO NOT EDIT.",
+		Write:    true,
+		Deep:     0,
+	}
+
+	b := &bytes.Buffer{}
+	if _, err := New(WithWriter(b)).Generate(ctx, cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := os.ReadFile("../test/mapType/mapType_singleton.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if b.String() != string(result) {
+		t.Fatalf("result is not the same as expected : %s\n %s", diff(b.String(), string(result), 10), b.String())
+	}
+
+}
+
+func TestCallback(t *testing.T) {
+	ctx := context.Background()
+	cfg := Config{
+		Package:  "github.com/alh1m1k/gosingl/test/callbackType",
+		Target:   "CallbackType", //public Structure //value Structure(no ref)
+		Variable: "cbInstance",
+		Comment:  "random comment",
+		Write:    true,
+		Deep:     0,
+	}
+
+	b := &bytes.Buffer{}
+	if _, err := New(WithWriter(b)).Generate(ctx, cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := os.ReadFile("../test/callbackType/callbackType_singleton.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if b.String() != string(result) {
+		t.Fatalf("result is not the same as expected : %s\n %s", diff(b.String(), string(result), 10), b.String())
+	}
+
+}
+
+func TestSlice(t *testing.T) {
+	ctx := context.Background()
+	cfg := Config{
+		Package:  "github.com/alh1m1k/gosingl/test/arraySliceType",
+		Target:   "_arraySlice_",
+		Variable: "Instance",
+		Comment:  "Code generated by <git repo>. DO NOT EDIT.",
+		Write:    true,
+		Deep:     0,
+	}
+
+	b := &bytes.Buffer{}
+	if _, err := New(WithWriter(b)).Generate(ctx, cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := os.ReadFile("../test/arraySliceType/_arraySlice__singleton.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if b.String() != string(result) {
+		t.Fatalf("result is not the same as expected : %s\n %s", diff(b.String(), string(result), 10), b.String())
+	}
+
+}
+
+func TestEmpty(t *testing.T) {
+	ctx := context.Background()
+	cfg := Config{
+		Package:  "github.com/alh1m1k/gosingl/test/empty",
+		Target:   "empty", //public Structure //value Structure(no ref)
+		Variable: "Instance",
+		Comment:  "Code generated by <git repo>. DO NOT EDIT.",
+		Write:    true,
+		Deep:     0,
+	}
+
+	b := &bytes.Buffer{}
+	if _, err := New(WithWriter(b)).Generate(ctx, cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := os.ReadFile("../test/empty/empty_singleton.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if b.String() != string(result) {
+		t.Fatalf("result is not the same as expected : %s\n %s", diff(b.String(), string(result), 10), b.String())
+	}
+
+}
+
+func TestComposition(t *testing.T) {
+	ctx := context.Background()
+	cfg := Config{
+		Package:  "github.com/alh1m1k/gosingl/test/composition",
+		Target:   "composition", //public Structure //value Structure(no ref)
+		Variable: "Instance",
+		Comment:  "Code generated by <git repo>. DO NOT EDIT.",
+		Write:    true,
+		Deep:     0,
+	}
+
+	b := &bytes.Buffer{}
+	if _, err := New(WithWriter(b)).Generate(ctx, cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := os.ReadFile("../test/composition/composition_singleton.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if b.String() != string(result) {
+		t.Fatalf("result is not the same as expected : %s\n %s", diff(b.String(), string(result), 10), b.String())
+	}
+
+}
+
+func TestSplit(t *testing.T) {
+	ctx := context.Background()
+	cfg := Config{
+		Package:  "github.com/alh1m1k/gosingl/test/split",
+		Target:   "split", //public Structure //value Structure(no ref)
+		Variable: "Instance",
+		Comment:  "Code generated by <git repo>. DO NOT EDIT.",
+		Write:    true,
+		Deep:     0,
+	}
+
+	b := &bytes.Buffer{}
+	if _, err := New(WithWriter(b)).Generate(ctx, cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := os.ReadFile("../test/split/split_singleton.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if b.String() != string(result) {
+		t.Fatalf("result is not the same as expected : %s\n %s", diff(b.String(), string(result), 10), b.String())
+	}
+
+}
+
+func TestDeep1(t *testing.T) {
+	ctx := context.Background()
+	cfg := Config{
+		Package:  "github.com/alh1m1k/gosingl/test/deep",
+		Target:   "deep", //public Structure //value Structure(no ref)
+		Variable: "Instance",
+		Comment:  "Code generated by <git repo>. DO NOT EDIT.",
+		Write:    true,
+		Deep:     1,
+	}
+
+	b := &bytes.Buffer{}
+	if _, err := New(WithWriter(b)).Generate(ctx, cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := os.ReadFile("../test/deep/deep_1_singleton.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if b.String() != string(result) {
+		t.Fatalf("result is not the same as expected : %s\n %s", diff(b.String(), string(result), 10), b.String())
+	}
+
+}
+
+func TestDeep2(t *testing.T) {
+	ctx := context.Background()
+	cfg := Config{
+		Package:  "github.com/alh1m1k/gosingl/test/deep",
+		Target:   "deep", //public Structure //value Structure(no ref)
+		Variable: "Instance",
+		Comment:  "Code generated by <git repo>. DO NOT EDIT.",
+		Write:    true,
+		Deep:     2,
+	}
+
+	b := &bytes.Buffer{}
+	if _, err := New(WithWriter(b)).Generate(ctx, cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := os.ReadFile("../test/deep/deep_2_singleton.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if b.String() != string(result) {
+		t.Fatalf("result is not the same as expected : %s\n %s", diff(b.String(), string(result), 10), b.String())
+	}
+
+}
+
+func TestDeepInf(t *testing.T) {
+	ctx := context.Background()
+	cfg := Config{
+		Package:  "github.com/alh1m1k/gosingl/test/deep",
+		Target:   "deep", //public Structure //value Structure(no ref)
+		Variable: "Instance",
+		Comment:  "Code generated by <git repo>. DO NOT EDIT.",
+		Write:    true,
+		Deep:     0,
+	}
+
+	b := &bytes.Buffer{}
+	if _, err := New(WithWriter(b)).Generate(ctx, cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := os.ReadFile("../test/deep/deep_singleton.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if b.String() != string(result) {
+		t.Fatalf("result is not the same as expected : %s\n %s", diff(b.String(), string(result), 10), b.String())
+	}
+
+}
+
+func TestMapTarget(t *testing.T) {
+	ctx := context.Background()
+	cfg := Config{
+		Package:  "github.com/alh1m1k/gosingl/test/mapTarget",
+		Target:   "mapTarget", //public Structure //value Structure(no ref)
+		Variable: "Instance",
+		Comment:  "Code generated by <git repo>. DO NOT EDIT.",
+		Write:    true,
+		Deep:     0,
+	}
+
+	b := &bytes.Buffer{}
+	if _, err := New(WithWriter(b)).Generate(ctx, cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := os.ReadFile("../test/mapTarget/mapTarget_singleton.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if b.String() != string(result) {
+		t.Fatalf("result is not the same as expected : %s\n %s", diff(b.String(), string(result), 10), b.String())
+	}
+
+}
+
+func TestArrayTarget(t *testing.T) {
+	ctx := context.Background()
+	cfg := Config{
+		Package:  "github.com/alh1m1k/gosingl/test/arrayTarget",
+		Target:   "arrayTarget", //public Structure //value Structure(no ref)
+		Variable: "Instance",
+		Comment:  "Code generated by <git repo>. DO NOT EDIT.",
+		Write:    true,
+		Deep:     0,
+	}
+
+	b := &bytes.Buffer{}
+	if _, err := New(WithWriter(b)).Generate(ctx, cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := os.ReadFile("../test/arrayTarget/arrayTarget_singleton.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if b.String() != string(result) {
+		t.Fatalf("result is not the same as expected : %s\n %s", diff(b.String(), string(result), 10), b.String())
+	}
+
+}
+
+func TestSliceTarget(t *testing.T) {
+	ctx := context.Background()
+	cfg := Config{
+		Package:  "github.com/alh1m1k/gosingl/test/sliceTarget",
+		Target:   "sliceTarget", //public Structure //value Structure(no ref)
+		Variable: "Instance",
+		Comment:  "Code generated by <git repo>. DO NOT EDIT.",
+		Write:    true,
+		Deep:     0,
+	}
+
+	b := &bytes.Buffer{}
+	if _, err := New(WithWriter(b)).Generate(ctx, cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := os.ReadFile("../test/sliceTarget/sliceTarget_singleton.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if b.String() != string(result) {
+		t.Fatalf("result is not the same as expected : %s\n %s", diff(b.String(), string(result), 10), b.String())
+	}
+
+}
+
+func TestInterfaceValidation1(t *testing.T) {
+	ctx := context.Background()
+	cfg := Config{
+		Package:  "github.com/alh1m1k/gosingl/test/interfaceValidation",
+		Target:   "interfaceValidationInvalid1", //public Structure //value Structure(no ref)
+		Variable: "Instance",
+		Comment:  "Code generated by <git repo>. DO NOT EDIT.",
+		Write:    true,
+		Deep:     0,
+	}
+
+	b := &bytes.Buffer{}
+	if _, err := New(WithWriter(b)).Generate(ctx, cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := os.ReadFile("../test/interfaceValidation/interfaceValidationInvalid1_singleton.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if b.String() != string(result) {
+		t.Fatalf("result is not the same as expected : %s\n %s", diff(b.String(), string(result), 10), b.String())
+	}
+
+}
+
+func TestInterfaceValidation2(t *testing.T) {
+	ctx := context.Background()
+	cfg := Config{
+		Package:  "github.com/alh1m1k/gosingl/test/interfaceValidation",
+		Target:   "interfaceValidationValid1", //public Structure //value Structure(no ref)
+		Variable: "Instance",
+		Comment:  "Code generated by <git repo>. DO NOT EDIT.",
+		Write:    true,
+		Deep:     0,
+	}
+
+	b := &bytes.Buffer{}
+	if _, err := New(WithWriter(b)).Generate(ctx, cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := os.ReadFile("../test/interfaceValidation/interfaceValidationValid1_singleton.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if b.String() != string(result) {
+		t.Fatalf("result is not the same as expected : %s\n %s", diff(b.String(), string(result), 10), b.String())
+	}
+
+}
+
+func TestAPI(t *testing.T) {
+	ctx := context.Background()
+	cfg := Config{
+		Package:  "github.com/alh1m1k/gosingl/test/api",
+		Target:   "api", //embedded members from several packages
+		Variable: "Instance",
+		Comment:  "Code generated by <git repo>. DO NOT EDIT.",
+		Write:    true,
+		Verify:   true,
+		Deep:     0,
+		API:      true,
+	}
+
+	b := &bytes.Buffer{}
+	if _, err := New(WithWriter(b)).Generate(ctx, cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := os.ReadFile("../test/api/api_singleton.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if b.String() != string(result) {
+		t.Fatalf("result is not the same as expected : %s\n %s", diff(b.String(), string(result), 10), b.String())
+	}
+
+}
+
+func TestFake(t *testing.T) {
+	ctx := context.Background()
+	cfg := Config{
+		Package:  "github.com/alh1m1k/gosingl/test/interfaceType",
+		Target:   "interfaceType",
+		Variable: "Instance",
+		Comment:  "Code generated by <git repo>. DO NOT EDIT.",
+		Write:    true,
+		Verify:   true,
+		Deep:     0,
+		Mode:     FakeMode,
+	}
+
+	b := &bytes.Buffer{}
+	if _, err := New(WithWriter(b)).Generate(ctx, cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := os.ReadFile("../test/interfaceType/interfaceType_singleton_fake.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if b.String() != string(result) {
+		t.Fatalf("result is not the same as expected : %s\n %s", diff(b.String(), string(result), 10), b.String())
+	}
+
+}
+
+func TestFakeGenerics(t *testing.T) {
+	ctx := context.Background()
+	cfg := Config{
+		Package:  "github.com/alh1m1k/gosingl/test/generics",
+		Target:   "generics",
+		Variable: "g[int, bool, *os.File]", //fake uses resolved instantiation
+		Comment:  "Code generated by <git repo>. DO NOT EDIT.",
+		Write:    true,
+		Deep:     0,
+		Mode:     FakeMode,
+	}
+
+	b := &bytes.Buffer{}
+	if _, err := New(WithWriter(b)).Generate(ctx, cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := os.ReadFile("../test/generics/generics_singleton_fake.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if b.String() != string(result) {
+		t.Fatalf("result is not the same as expected : %s\n %s", diff(b.String(), string(result), 10), b.String())
+	}
+
+}
+
+func TestForwardFacade(t *testing.T) {
+	ctx := context.Background()
+	cfg := Config{
+		Package:  "github.com/alh1m1k/gosingl/test/forward",
+		Target:   "forward", //variadic and anonymous parameters
+		Variable: "Instance",
+		Comment:  "Code generated by <git repo>. DO NOT EDIT.",
+		Write:    true,
+		Deep:     0,
+	}
+
+	b := &bytes.Buffer{}
+	if _, err := New(WithWriter(b)).Generate(ctx, cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := os.ReadFile("../test/forward/forward_singleton.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if b.String() != string(result) {
+		t.Fatalf("result is not the same as expected : %s\n %s", diff(b.String(), string(result), 10), b.String())
+	}
+
+}
+
+func TestForwardTest(t *testing.T) {
+	ctx := context.Background()
+	cfg := Config{
+		Package:  "github.com/alh1m1k/gosingl/test/forward",
+		Target:   "forward",
+		Variable: "Instance",
+		Comment:  "Code generated by <git repo>. DO NOT EDIT.",
+		Write:    true,
+		Verify:   true,
+		Deep:     0,
+		Mode:     TestMode,
+	}
+
+	b := &bytes.Buffer{}
+	if _, err := New(WithWriter(b)).Generate(ctx, cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := os.ReadFile("../test/forward/forward_singleton_test.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if b.String() != string(result) {
+		t.Fatalf("result is not the same as expected : %s\n %s", diff(b.String(), string(result), 10), b.String())
+	}
+	//tests call the facade, it must be generated before
+	for _, missing := range []Config{{Variable: "Missing"}, {Variable: "Instance", Rename: "Join=Concat"}} {
+		cfg.Variable, cfg.Rename = missing.Variable, missing.Rename
+		if _, err := New(WithWriter(&bytes.Buffer{})).Generate(ctx, cfg); !errors.Is(err, ForwardFacadeError) {
+			t.Fatalf("expected %v, got %v", ForwardFacadeError, err)
+		}
+	}
+}
+
+func TestForwardTestInterface(t *testing.T) {
+	ctx := context.Background()
+	cfg := Config{
+		Package:  "github.com/alh1m1k/gosingl/test/interfaceType",
+		Target:   "interfaceType",
+		Variable: "Instance",
+		Comment:  "Code generated by <git repo>. DO NOT EDIT.",
+		Write:    true,
+		Deep:     0,
+		Mode:     TestMode,
+	}
+
+	b := &bytes.Buffer{}
+	if _, err := New(WithWriter(b)).Generate(ctx, cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := os.ReadFile("../test/interfaceType/interfaceType_singleton_test.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if b.String() != string(result) {
+		t.Fatalf("result is not the same as expected : %s\n %s", diff(b.String(), string(result), 10), b.String())
+	}
+
+}
+
+func TestForwardTestStruct(t *testing.T) {
+	ctx := context.Background()
+	cfg := Config{
+		Package:  "github.com/alh1m1k/gosingl/test/api",
+		Target:   "api", //stand-in may not be installed instead of structure
+		Variable: "Instance",
+		Write:    true,
+		Mode:     TestMode,
+	}
+
+	b := &bytes.Buffer{}
+	if _, err := New(WithWriter(b)).Generate(ctx, cfg); !errors.Is(err, ForwardTargetError) {
+		t.Fatalf("expected %v, got %v", ForwardTargetError, err)
+	}
+}
+
+func TestRPC(t *testing.T) {
+	ctx := context.Background()
+	cfg := Config{
+		Package:  "github.com/alh1m1k/gosingl/test/rpc",
+		Target:   "calc", //channel parameter is not supported
+		Variable: "Instance",
+		Comment:  "Code generated by <git repo>. DO NOT EDIT.",
+		Write:    true,
+		Verify:   true,
+		Deep:     0,
+		Mode:     RPCMode,
+	}
+
+	b := &bytes.Buffer{}
+	if _, err := New(WithWriter(b)).Generate(ctx, cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := os.ReadFile("../test/rpc/calc_singleton_rpc.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if b.String() != string(result) {
+		t.Fatalf("result is not the same as expected : %s\n %s", diff(b.String(), string(result), 10), b.String())
+	}
+
+}
+
+func TestRPCClient(t *testing.T) {
+	ctx := context.Background()
+	cfg := Config{
+		Package: "github.com/alh1m1k/gosingl/test/rpc",
+		Target:  "calc",
+		Comment: "Code generated by <git repo>. DO NOT EDIT.",
+		Write:   true,
+		Verify:  true,
+		Deep:    0,
+		Mode:    RPCClientMode,
+	}
+
+	b := &bytes.Buffer{}
+	if _, err := New(WithWriter(b)).Generate(ctx, cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := os.ReadFile("../test/rpc/calc_singleton_rpc_client.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if b.String() != string(result) {
+		t.Fatalf("result is not the same as expected : %s\n %s", diff(b.String(), string(result), 10), b.String())
+	}
+
+}
+
+func TestRPCStrict(t *testing.T) {
+	cfg := Config{
+		Package: "github.com/alh1m1k/gosingl/test/rpc",
+		Target:  "calc", //Add has no error result for the transport error
+		Mode:    RPCClientMode,
+		Strict:  true,
+	}
+
+	b := &bytes.Buffer{}
+	if _, err := New(WithWriter(b)).Generate(context.Background(), cfg); !errors.Is(err, RPCSignatureError) {
+		t.Fatalf("%v is not %v", err, RPCSignatureError)
+	}
+}
+
+func diff(generated, reference string, offset int) string {
+	log.Println("str1:", len(generated), "str2:", len(reference))
+	for i := 0; i < len(generated); i++ {
+		if len(reference) <= i {
+			continue
+		}
+		if generated[i] != reference[i] {
+			offset = min[int](offset, len(generated)-i)
+			offset = min[int](offset, len(reference)-i)
+			return fmt.Sprintf("symbol %d: gen: \"%s\" ref: \"%s\"", i, generated[i:i+offset], reference[i:i+offset])
+		}
+	}
+	return ""
+}
+
+func min[T int](s ...T) T {
+	if len(s) == 0 {
+		var zero T
+		return zero
+	}
+	m := s[0]
+	for _, v := range s {
+		if m > v {
+			m = v
+		}
+	}
+	return m
+}
+
+func TestReexport(t *testing.T) {
+	ctx := context.Background()
+	cfg := Config{
+		Package: "github.com/alh1m1k/gosingl/test/reexport/source",
+		Target:  "github.com/alh1m1k/gosingl/test/reexport",
+		Comment: "Code generated by <git repo>. DO NOT EDIT.",
+		Write:   true,
+		Verify:  true,
+		Mode:    ReexportMode,
+		Exclude: "^Internal",
+	}
+
+	b := &bytes.Buffer{}
+	if _, err := New(WithWriter(b)).Generate(ctx, cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := os.ReadFile("../test/reexport/source_reexport.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if b.String() != string(result) {
+		t.Fatalf("result is not the same as expected : %s\n %s", diff(b.String(), string(result), 10), b.String())
+	}
+}
+
+func TestOutPackage(t *testing.T) {
+	ctx := context.Background()
+	cfg := Config{
+		Package:    "github.com/alh1m1k/gosingl/test/outPackage",
+		Target:     "Store",
+		Variable:   "Instance",
+		Comment:    "Code generated by <git repo>. DO NOT EDIT.",
+		Write:      true,
+		Verify:     true,
+		OutPackage: "github.com/alh1m1k/gosingl/test/outPackage/facade",
+	}
+
+	b := &bytes.Buffer{}
+	if _, err := New(WithWriter(b)).Generate(ctx, cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := os.ReadFile("../test/outPackage/facade/store_singleton.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if b.String() != string(result) {
+		t.Fatalf("result is not the same as expected : %s\n %s", diff(b.String(), string(result), 10), b.String())
+	}
+}
+
+func TestOutPackageInaccessible(t *testing.T) {
+	ctx := context.Background()
+	for _, cfg := range []Config{
+		{
+			Package:    "github.com/alh1m1k/gosingl/test/outPackage",
+			Target:     "hidden", //unexported target
+			OutPackage: "github.com/alh1m1k/gosingl/test/outPackage/facade",
+		},
+		{
+			Package:    "github.com/alh1m1k/gosingl/test/outPackage",
+			Target:     "Leaky", //unexported type in signature
+			OutPackage: "github.com/alh1m1k/gosingl/test/outPackage/facade",
+		},
+		{
+			Package:    "github.com/alh1m1k/gosingl/test/outPackage/internal/store",
+			Target:     "Store", //internal package outside of the tree
+			OutPackage: "github.com/alh1m1k/gosingl/test/api",
+		},
+	} {
+		b := &bytes.Buffer{}
+		if _, err := New(WithWriter(b)).Generate(ctx, cfg); !errors.Is(err, OutPackageError) {
+			t.Fatalf("%s: expected %v, got %v", cfg.Target, OutPackageError, err)
+		}
+	}
+}
+
+func TestLeak(t *testing.T) {
+	ctx := context.Background()
+	cfg := Config{
+		Package:  "github.com/alh1m1k/gosingl/test/leak",
+		Target:   "Client", //promoted Dial and Hooks refer to unexported type of other package
+		Variable: "Instance",
+		Comment:  "Code generated by <git repo>. DO NOT EDIT.",
+		Write:    true,
+		Verify:   true,
+	}
+
+	b := &bytes.Buffer{}
+	if _, err := New(WithWriter(b)).Generate(ctx, cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := os.ReadFile("../test/leak/client_singleton.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if b.String() != string(result) {
+		t.Fatalf("result is not the same as expected : %s\n %s", diff(b.String(), string(result), 10), b.String())
+	}
+}
+
+func TestLeakStrict(t *testing.T) {
+	ctx := context.Background()
+	cfg := Config{
+		Package:  "github.com/alh1m1k/gosingl/test/leak",
+		Target:   "Client",
+		Variable: "Instance",
+		Write:    true,
+		Strict:   true,
+	}
+
+	b := &bytes.Buffer{}
+	if _, err := New(WithWriter(b)).Generate(ctx, cfg); !errors.Is(err, OutPackageError) {
+		t.Fatalf("expected %v, got %v", OutPackageError, err)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	ctx := context.Background()
+	cfg := Config{
+		Package:  "github.com/alh1m1k/gosingl/test/leak",
+		Target:   "Client",
+		Variable: "State", //collides with proxy of the State method
+		Write:    true,
+		Verify:   true,
+	}
+
+	b := &bytes.Buffer{}
+	_, err := New(WithWriter(b)).Generate(ctx, cfg)
+	if !errors.Is(err, VerifyError) {
+		t.Fatalf("expected %v, got %v", VerifyError, err)
+	}
+	if !strings.Contains(err.Error(), "test/leak/leak.go:11") {
+		t.Fatalf("error is not mapped to the source function: %v", err)
+	}
+	if b.Len() > 0 {
+		t.Fatalf("code which does not compile is written: %s", b.String())
+	}
+}
+
+func TestAtomicWrite(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	ctx := context.Background()
+	dir := t.TempDir()
+	cfg := Config{
+		Package:  "github.com/alh1m1k/gosingl/test/leak",
+		Target:   "Client",
+		Variable: "Instance",
+		Comment:  "Code generated by <git repo>. DO NOT EDIT.",
+		Path:     dir + "/client_singleton.go",
+	}
+	expected, err := os.ReadFile("../test/leak/client_singleton.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//new file
+	if err = ParsePackage(ctx, cfg); err != nil {
+		t.Fatal(err)
+	}
+	if stat, err := os.Stat(cfg.Path); err != nil || stat.Mode().Perm() != 0644 {
+		t.Fatalf("expected mode %v, got %v (%v)", os.FileMode(0644), stat, err)
+	}
+
+	//previous file keeps the mode
+	if err = os.WriteFile(cfg.Path, []byte("previous"), 0640); err != nil {
+		t.Fatal(err)
+	}
+	if err = os.Chmod(cfg.Path, 0640); err != nil {
+		t.Fatal(err)
+	}
+	if err = ParsePackage(ctx, cfg); err != nil {
+		t.Fatal(err)
+	}
+	if stat, err := os.Stat(cfg.Path); err != nil || stat.Mode().Perm() != 0640 {
+		t.Fatalf("expected mode %v, got %v (%v)", os.FileMode(0640), stat, err)
+	}
+	if result, _ := os.ReadFile(cfg.Path); string(result) != string(expected) {
+		t.Fatalf("result is not the same as expected : %s", diff(string(result), string(expected), 10))
+	}
+
+	//failed run leaves previous file untouched
+	if err = os.WriteFile(cfg.Path, []byte("previous"), 0640); err != nil {
+		t.Fatal(err)
+	}
+	cfg.Variable, cfg.Verify = "State", true
+	if err = ParsePackage(ctx, cfg); !errors.Is(err, VerifyError) {
+		t.Fatalf("expected %v, got %v", VerifyError, err)
+	}
+	if result, _ := os.ReadFile(cfg.Path); string(result) != "previous" {
+		t.Fatalf("previous file is modified: %s", result)
+	}
+
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Fatalf("temp files are left: %v", entries)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	ctx := context.Background()
+	cfg := Config{
+		Package:  "github.com/alh1m1k/gosingl/test/leak",
+		Target:   "Client",
+		Variable: "Instance",
+		Comment:  "Code generated by <git repo>. DO NOT EDIT.",
+		Check:    true,
+	}
+
+	//up to date
+	b := &bytes.Buffer{}
+	if _, err := New(WithWriter(b)).Generate(ctx, cfg); err != nil {
+		t.Fatal(err)
+	}
+	if b.Len() > 0 {
+		t.Fatalf("unexpected diff: %s", b.String())
+	}
+
+	//stale
+	expected, err := os.ReadFile("../test/leak/client_singleton.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.Path = t.TempDir() + "/client_singleton.go"
+	stale := strings.Replace(string(expected), "func Ping() error {", "func Ping() (err error) {", 1)
+	if err = os.WriteFile(cfg.Path, []byte(stale), 0644); err != nil {
+		t.Fatal(err)
+	}
+	b = &bytes.Buffer{}
+	if _, err = New(WithWriter(b)).Generate(ctx, cfg); !errors.Is(err, StaleError) {
+		t.Fatalf("expected %v, got %v", StaleError, err)
+	}
+	if !strings.Contains(b.String(), "@@ -13,6 +13,6 @@\n \n // <conn.Conn> from github.com/alh1m1k/gosingl/test/leak/conn\n \n-func Ping() (err error) {\n+func Ping() error {\n") {
+		t.Fatalf("unexpected diff: %s", b.String())
+	}
+
+	//diff only
+	cfg.Check, cfg.Diff = false, true
+	b = &bytes.Buffer{}
+	if _, err = New(WithWriter(b)).Generate(ctx, cfg); err != nil {
+		t.Fatal(err)
+	}
+	if b.Len() == 0 {
+		t.Fatal("diff is expected")
+	}
+	if result, _ := os.ReadFile(cfg.Path); string(result) != stale {
+		t.Fatalf("file is modified: %s", result)
+	}
+}
+
+func TestRegen(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	ctx := context.Background()
+	dir := t.TempDir()
+	expected, err := os.ReadFile("../test/leak/client_singleton.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	stale := strings.Replace(string(expected), "func Ping() error {", "func Ping() (err error) {", 1)
+	if err = os.WriteFile(dir+"/client_singleton.go", []byte(stale), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err = os.WriteFile(dir+"/hand.go", []byte("package leak\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = New().Regenerate(ctx, []string{dir + "/..."}); err != nil {
+		t.Fatal(err)
+	}
+	if result, _ := os.ReadFile(dir + "/client_singleton.go"); string(result) != string(expected) {
+		t.Fatalf("result is not the same as expected : %s", diff(string(result), string(expected), 10))
+	}
+
+	if err = Clean([]string{dir}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = os.Stat(dir + "/client_singleton.go"); !os.IsNotExist(err) {
+		t.Fatalf("generated file is not removed: %v", err)
+	}
+	if _, err = os.Stat(dir + "/hand.go"); err != nil {
+		t.Fatalf("regular file is removed: %v", err)
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	ctx := context.Background()
+	cfg := Config{
+		Package:  "github.com/alh1m1k/gosingl/test/leak",
+		Target:   "Client",
+		Variable: "Instance",
+		Comment:  "Code generated by <git repo>. DO NOT EDIT.",
+		Suffix:   "_singleton.go",
+		Path:     t.TempDir() + "/client_singleton.go",
+		Write:    true,
+	}
+
+	if upToDate(cfg) {
+		t.Fatal("nothing is generated yet")
+	}
+	if err := ParsePackage(ctx, cfg); err != nil {
+		t.Fatal(err)
+	}
+	if !upToDate(cfg) {
+		t.Fatal("inputs are not changed")
+	}
+
+	//output is a part of the fingerprint
+	if err := os.WriteFile(cfg.Path, []byte("package leak\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if upToDate(cfg) {
+		t.Fatal("generated file is changed")
+	}
+	if err := ParsePackage(ctx, cfg); err != nil {
+		t.Fatal(err)
+	}
+	if !upToDate(cfg) {
+		t.Fatal("file is regenerated")
+	}
+
+	//config is a part of the fingerprint
+	cfg.Strict = true
+	if upToDate(cfg) {
+		t.Fatal("config is changed")
+	}
+
+	cfg.Force = true
+	if cacheable(newRun(New(), nil), cfg) {
+		t.Fatal("force ignores fingerprint")
+	}
+	//unresolved output is a miss, generation reports it
+	missing := Config{Package: "github.com/alh1m1k/gosingl/test/nothing", Target: "Client", Suffix: "_singleton.go", Write: true}
+	if upToDate(missing) {
+		t.Fatal("output of the missing package is not resolved")
+	}
+	if err := ParsePackage(ctx, missing); err == nil {
+		t.Fatal("missing package is reported")
+	}
+}
+
+func TestExportCache(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	ctx := context.Background()
+	cfg := Config{
+		Package:  "github.com/alh1m1k/gosingl/test/api",
+		Target:   "api",
+		Variable: "Instance",
+		Comment:  "Code generated by <git repo>. DO NOT EDIT.",
+		Write:    true,
+		API:      true,
+	}
+	result, err := os.ReadFile("../test/api/api_singleton.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//the first run fills the cache, the second one is served by it
+	for _, state := range []string{"cold", "warm"} {
+		b := &bytes.Buffer{}
+		if _, err := New(WithWriter(b)).Generate(ctx, cfg); err != nil {
+			t.Fatal(err)
+		}
+		if b.String() != string(result) {
+			t.Fatalf("%s cache: result is not the same as expected : %s", state, diff(b.String(), string(result), 10))
+		}
+	}
+
+	imp := newExportImporter(exportDir())
+	pkg, err := imp.Import("strings")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if imp.hits != 1 || pkg.Scope().Lookup("Reader") == nil {
+		t.Fatalf("export data of the strings is not cached, hits %d", imp.hits)
+	}
+	if _, err = imp.Import("github.com/alh1m1k/gosingl/test/leak/conn"); err == nil {
+		t.Fatal("only the standard library is imported")
+	}
+
+	//outdated entries are evicted on the next store
+	entries, err := os.ReadDir(exportDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	outdated := time.Now().Add(-exportTTL - time.Hour)
+	for _, entry := range entries {
+		if err = os.Chtimes(filepath.Join(exportDir(), entry.Name()), outdated, outdated); err != nil {
+			t.Fatal(err)
+		}
+	}
+	imp = newExportImporter(exportDir())
+	if _, err = imp.Import("container/ring"); err != nil {
+		t.Fatal(err)
+	}
+	if entries, _ = os.ReadDir(exportDir()); len(entries) != 1 {
+		t.Fatalf("outdated entries are not evicted, %d left", len(entries))
+	}
+}
+
+func TestSourceFiles(t *testing.T) {
+	cfg := Config{
+		Package:      "github.com/alh1m1k/gosingl/test/sources",
+		ExcludeFiles: "extra_*.go, *.bak.go",
+	}
+	_, files, _, err := collectFiles(cfg.Package, []string{"_test.go"}, sourceFiles(cfg, true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := make([]string, 0, len(files))
+	for path := range files {
+		names = append(names, filepath.Base(path))
+	}
+	sort.Strings(names)
+	//custom.go, facade.go, legacy_singleton.go and team.go (custom comment) are outputs of the gosingl,
+	//kind_string.go is generated by other tool, manual_singleton.go is written by hand
+	if expected := []string{"kind.go", "kind_string.go", "manual_singleton.go", "sources.go"}; fmt.Sprint(names) != fmt.Sprint(expected) {
+		t.Fatalf("expected %v, got %v", expected, names)
+	}
+
+	cfg.ExcludeFiles, cfg.IncludeFiles = "", "kind*.go"
+	if _, files, _, err = collectFiles(cfg.Package, []string{"_test.go"}, sourceFiles(cfg, true)); err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("expected kind.go and kind_string.go, got %v", files)
+	}
+
+	//outputs are looked for in the target package only
+	cfg.IncludeFiles = ""
+	if _, files, _, err = collectFiles(cfg.Package, []string{"_test.go"}, sourceFiles(cfg, false)); err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 9 {
+		t.Fatalf("expected every file of the package, got %d", len(files))
+	}
+}
+
+func TestProject(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	ctx := context.Background()
+	dir := t.TempDir()
+	project := `{
+	"targets": [
+		{"pkg": "github.com/alh1m1k/gosingl/test/leak", "target": "Client", "filepath": "client_singleton.go"},
+		{"pkg": "github.com/alh1m1k/gosingl/test/leak", "target": "Client", "mode": "fake", "filepath": "client_fake.go"}
+	]
+}`
+	if err := os.WriteFile(dir+"/"+ProjectFile, []byte(project), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(dir+"/go.mod", []byte("module project\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(dir+"/nested", 0755); err != nil {
+		t.Fatal(err)
+	}
+	path, err := FindProject(dir + "/nested")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = New().GenerateProject(ctx, path, Config{}); err != nil {
+		t.Fatal(err)
+	}
+	expected, err := os.ReadFile("../test/leak/client_singleton.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result, _ := os.ReadFile(dir + "/client_singleton.go"); string(result) != string(expected) {
+		t.Fatalf("result is not the same as expected : %s", diff(string(result), string(expected), 10))
+	}
+	if result, _ := os.ReadFile(dir + "/client_fake.go"); !strings.Contains(string(result), "type FakeClient struct") {
+		t.Fatalf("fake is not generated: %s", result)
+	}
+
+	facade, fake := Config{}, Config{Mode: FakeMode}
+	if selectionKey(New(), facade) == selectionKey(New(), fake) {
+		t.Fatal("facade and fake targets must not share parsed packages")
+	}
+	if selectionKey(New(), facade) != selectionKey(New(), Config{Suffix: "_singleton.go"}) {
+		t.Fatal("default suffix is the suffix of the mode")
+	}
+
+	if err = os.WriteFile(path, []byte(`{"targets": [{"pkg": "x", "target": "Y", "varaible": "z"}]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err = New().GenerateProject(ctx, path, Config{}); !errors.Is(err, ProjectError) {
+		t.Fatalf("expected %v, got %v", ProjectError, err)
+	}
+}
+
+func TestAnnotations(t *testing.T) {
+	if err := New(WithWriter(&bytes.Buffer{})).GenerateAnnotated(context.Background(), "../test/annotated/...", Config{Check: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	if err := os.WriteFile(dir+"/go.mod", []byte("module annotated\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	source := "package annotated\n\n//gosingl:generate varaible=db\ntype A struct{}\n\n//gosingl:generate deep=x\ntype B struct{}\n"
+	if err := os.WriteFile(dir+"/annotated.go", []byte(source), 0644); err != nil {
+		t.Fatal(err)
+	}
+	found, errs := discoverAnnotations(dir)
+	if len(found) != 0 || len(errs) != 2 {
+		t.Fatalf("expected 2 errors, got %v %v", found, errs)
+	}
+	for i, line := range []string{":3:1", ":6:1"} {
+		if !errors.Is(errs[i], AnnotationError) || !strings.Contains(errs[i].Error(), "annotated.go"+line) {
+			t.Fatalf("expected positioned %v, got %v", AnnotationError, errs[i])
+		}
+	}
+}
+
+func TestGoGenerateTarget(t *testing.T) {
+	t.Setenv("GOFILE", "annotated.go")
+	t.Setenv("GOLINE", "22")
+	t.Setenv("GOPACKAGE", "annotated")
+	pkg, target, err := GoGenerateTarget("../test/annotated")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pkg != "github.com/alh1m1k/gosingl/test/annotated" || target != "Cache" {
+		t.Fatalf("unexpected target %s.%s", pkg, target)
+	}
+
+	t.Setenv("GOLINE", "30")
+	if _, _, err = GoGenerateTarget("../test/annotated"); !errors.Is(err, NotFoundError) {
+		t.Fatalf("expected %v, got %v", NotFoundError, err)
+	}
+}
+
+// lockedBuffer output of the goroutine which is read by the test
+type lockedBuffer struct {
+	sync.Mutex
+	bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.Lock()
+	defer b.Unlock()
+	return b.Buffer.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.Lock()
+	defer b.Unlock()
+	return b.Buffer.String()
+}
+
+func TestWatch(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	//fixture is copied, so the checked in files are not touched
+	module := t.TempDir()
+	copies := map[string]string{
+		"../test/leak/leak.go":      module + "/leak/leak.go",
+		"../test/leak/conn/conn.go": module + "/leak/conn/conn.go",
+	}
+	for from, to := range copies {
+		content, err := os.ReadFile(from)
+		if err != nil {
+			t.Fatal(err)
+		}
+		content = bytes.ReplaceAll(content, []byte("github.com/alh1m1k/gosingl/test/leak"), []byte("watched/leak"))
+		if err = os.MkdirAll(filepath.Dir(to), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err = os.WriteFile(to, content, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(module+"/go.mod", []byte("module watched\n\ngo 1.20\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = os.Chdir(module); err != nil { //packages are resolved from the working directory
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	dir := t.TempDir()
+	err = ParsePackage(context.Background(), Config{
+		Package: "watched/leak",
+		Target:  "Client",
+		Comment: "Code generated by <git repo>. DO NOT EDIT.",
+		Path:    dir + "/client_singleton.go",
+		Write:   true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	out, done := &lockedBuffer{}, make(chan error)
+	go func() {
+		done <- Watch(ctx, []string{dir}, 10*time.Millisecond, 30*time.Millisecond, out)
+	}()
+	wait := func(text string, count int) {
+		for deadline := time.Now().Add(5 * time.Second); strings.Count(out.String(), text) < count; {
+			if time.Now().After(deadline) {
+				cancel()
+				t.Fatalf("%q is expected %d times: %s", text, count, out.String())
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+	wait("watching 1 files", 1)
+
+	//embedded type of the other package is the input too
+	input := module + "/leak/conn/conn.go"
+	stat, err := os.Stat(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = os.Chtimes(input, time.Now(), stat.ModTime().Add(time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	wait("client_singleton.go: unchanged", 2)
+
+	cancel()
+	if err = <-done; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGenerator(t *testing.T) {
+	cfg := Config{
+		Package:  "github.com/alh1m1k/gosingl/test/leak",
+		Target:   "Client",
+		Variable: "Instance",
+		Comment:  "Code generated by <git repo>. DO NOT EDIT.",
+	}
+	expected, err := os.ReadFile("../test/leak/client_singleton.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b := &bytes.Buffer{}
+	result, err := New(WithWriter(b)).Generate(context.Background(), cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(result.Code) != string(expected) || b.String() != string(expected) {
+		t.Fatalf("result is not the same as expected : %s", diff(string(result.Code), string(expected), 10))
+	}
+	if result.Written || result.Path != "" {
+		t.Fatalf("file is not expected: %+v", result)
+	}
+	if len(result.Diagnostics) != 2 || !strings.Contains(result.Diagnostics[1], "Hooks") {
+		t.Fatalf("skipped functions are expected in diagnostics: %v", result.Diagnostics)
+	}
+
+	//file which declares the target is not parsed
+	generator := New(WithWriter(&bytes.Buffer{}), WithFileFilter(func(path string) bool {
+		return filepath.Base(path) != "leak.go"
+	}))
+	if result, err = generator.Generate(context.Background(), cfg); err == nil {
+		t.Fatalf("unexpected result: %s", result.Code)
+	}
+}
+
+func TestConcurrentGeneration(t *testing.T) {
+	files, err := generatedFiles("../test/...")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		wg     sync.WaitGroup
+		mux    sync.Mutex
+		failed []string
+	)
+	for round := 0; round < 3; round++ {
+		for _, path := range files {
+			if strings.HasSuffix(path, "composition_singleton.go") || strings.HasSuffix(path, "deep/deep_singleton.go") || strings.HasSuffix(path, "sources/custom.go") {
+				continue //golden files which differ from the current output, hand written file
+			}
+			cfg, _, err := readHeader(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			expected, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			wg.Add(1)
+			go func(path string, cfg Config) {
+				defer wg.Done()
+				b := &bytes.Buffer{}
+				_, err := New(WithWriter(b)).Generate(context.Background(), cfg)
+				if err != nil || b.String() != string(expected) {
+					mux.Lock()
+					failed = append(failed, fmt.Sprintf("%s: %v %s", path, err, diff(b.String(), string(expected), 10)))
+					mux.Unlock()
+				}
+			}(path, cfg)
+		}
+	}
+	wg.Wait()
+	if len(failed) > 0 {
+		t.Fatalf("%d of %d generations differ:\n%s", len(failed), 3*len(files), strings.Join(failed, "\n"))
+	}
+}
+
+func TestCanceledGeneration(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	cfg := Config{Package: "github.com/alh1m1k/gosingl/test/deep", Target: "deep"}
+	if _, err := New(WithWriter(&bytes.Buffer{})).Generate(ctx, cfg); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected %v, got %v", context.Canceled, err)
+	}
+}
+
+func TestJobs(t *testing.T) {
+	var outputs []string
+	for _, jobs := range []int{1, 8} {
+		b := &bytes.Buffer{}
+		cfg := Config{Package: "github.com/alh1m1k/gosingl/test/sectionReader", Target: "sectionReader", Jobs: jobs}
+		if _, err := New(WithWriter(b)).Generate(context.Background(), cfg); err != nil {
+			t.Fatal(err)
+		}
+		outputs = append(outputs, b.String())
+	}
+	if outputs[0] != outputs[1] {
+		t.Fatalf("output depends on the number of jobs: %s", diff(outputs[0], outputs[1], 10))
+	}
+	if !strings.Contains(outputs[0], "Instance.SectionReader.ReadAt(") || !strings.Contains(outputs[0], "Instance.ReadWriter.Reader.ReadByte()") {
+		t.Fatalf("embedded members are not generated: %s", outputs[0])
+	}
+}
+
+func TestDescentRules(t *testing.T) {
+	cfg := Config{
+		Package: "github.com/alh1m1k/gosingl/test/sectionReader",
+		Target:  "sectionReader",
+		Comment: "Code generated by <git repo>. DO NOT EDIT.",
+		StopAt:  "std",
+	}
+	expected, err := os.ReadFile("../test/sectionReader/sectionReader_singleton.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := &bytes.Buffer{}
+	if _, err = New(WithWriter(b)).Generate(context.Background(), cfg); err != nil {
+		t.Fatal(err)
+	}
+	if b.String() != string(expected) {
+		t.Fatalf("result is not the same as expected : %s", diff(b.String(), string(expected), 10))
+	}
+
+	//members of the other modules are skipped, rules are explained
+	cfg = Config{Package: "github.com/alh1m1k/gosingl/test/deep", Target: "deep", Descend: "module", Leaf: LeafNone, Explain: true}
+	b = &bytes.Buffer{}
+	result, err := New(WithWriter(b)).Generate(context.Background(), cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(b.String(), "Ilvl2()") || strings.Contains(b.String(), "ReadByte") || strings.Contains(b.String(), "ReadAt") {
+		t.Fatalf("unexpected members: %s", b.String())
+	}
+	explained := strings.Join(result.Explain, "\n")
+	if !strings.Contains(explained, "io.SectionReader: skipped by --descend module") || !strings.Contains(explained, "deep.tl2: walked") {
+		t.Fatalf("rules are not explained: %s", explained)
+	}
+
+	cfg.Leaf = "all"
+	if _, err = New(WithWriter(&bytes.Buffer{})).Generate(context.Background(), cfg); err == nil {
+		t.Fatal("unknown leaf is accepted")
+	}
+
+	rules := &descentRules{module: "github.com/alh1m1k/gosingl"}
+	for pattern, pkg := range map[string]string{
+		"std":                          "net/http",
+		"module":                       "github.com/alh1m1k/gosingl/test/deep",
+		"net/...":                      "net/http",
+		"github.com/alh1m1k/gosingl":   "github.com/alh1m1k/gosingl",
+		"github.com/dave/jennifer/...": "github.com/dave/jennifer",
+	} {
+		if !rules.match(pattern, pkg) {
+			t.Errorf("%s does not match %s", pattern, pkg)
+		}
+	}
+	for pattern, pkg := range map[string]string{
+		"std":     "github.com/alh1m1k/gosingl/test/deep",
+		"module":  "github.com/alh1m1k/gosinglx",
+		"net/...": "network",
+	} {
+		if rules.match(pattern, pkg) {
+			t.Errorf("%s matches %s", pattern, pkg)
+		}
+	}
+}
+
+func TestFunctionFilter(t *testing.T) {
+	cfg := Config{
+		Package:     "github.com/alh1m1k/gosingl/test/sectionReader",
+		Target:      "sectionReader",
+		Comment:     "Code generated by <git repo>. DO NOT EDIT.",
+		Suffix:      "_pointer_singleton.go",
+		Exclude:     "^Seek$",
+		ExcludeFrom: "ReadWriter",
+		Receiver:    PointerReceiver,
+	}
+	expected, err := os.ReadFile("../test/sectionReader/testdata/sectionReader_pointer_singleton.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := &bytes.Buffer{}
+	result, err := New(WithWriter(b)).Generate(context.Background(), cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.String() != string(expected) {
+		t.Fatalf("result is not the same as expected : %s", diff(b.String(), string(expected), 10))
+	}
+	//excluded functions are not ambiguous
+	if len(result.Diagnostics) != 0 {
+		t.Fatalf("unexpected diagnostics: %v", result.Diagnostics)
+	}
+
+	cfg = Config{Package: cfg.Package, Target: cfg.Target, StopAt: "std", Receiver: ValueReceiver, IncludeFrom: "http.Header"}
+	b = &bytes.Buffer{}
+	if _, err = New(WithWriter(b)).Generate(context.Background(), cfg); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(b.String(), "Instance.Header.Add(") || strings.Contains(b.String(), "Rewind") || strings.Contains(b.String(), "ReadAt") {
+		t.Fatalf("unexpected functions: %s", b.String())
+	}
+
+	cfg = Config{Package: "github.com/alh1m1k/gosingl/test/deep", Target: "deep", ExcludeFrom: "tl2.SectionReader,il2"}
+	b = &bytes.Buffer{}
+	if _, err = New(WithWriter(b)).Generate(context.Background(), cfg); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(b.String(), "Ilvl1()") || strings.Contains(b.String(), "Ilvl2()") || strings.Contains(b.String(), "ReadAt") {
+		t.Fatalf("unexpected functions: %s", b.String())
+	}
+
+	cfg.Receiver = "interface"
+	if _, err = New(WithWriter(&bytes.Buffer{})).Generate(context.Background(), cfg); err == nil {
+		t.Fatal("unknown receiver is accepted")
+	}
+}
+
+func TestVia(t *testing.T) {
+	//facades of the same package redeclare the variable, second golden is kept in testdata
+	for golden, cfg := range map[string]Config{
+		"../test/via/store_singleton.go":         {Target: "store", Via: "Store"},
+		"../test/via/testdata/file_singleton.go": {Target: "file", Via: "io.ReadWriteCloser"},
+	} {
+		cfg.Package, cfg.Comment = "github.com/alh1m1k/gosingl/test/via", "Code generated by <git repo>. DO NOT EDIT."
+		expected, err := os.ReadFile(golden)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b := &bytes.Buffer{}
+		if _, err = New(WithWriter(b)).Generate(context.Background(), cfg); err != nil {
+			t.Fatal(err)
+		}
+		if b.String() != string(expected) {
+			t.Fatalf("result is not the same as expected : %s", diff(b.String(), string(expected), 10))
+		}
+	}
+
+	cfg := Config{Package: "github.com/alh1m1k/gosingl/test/via", Target: "broken", Via: "Store"}
+	_, err := New(WithWriter(&bytes.Buffer{})).Generate(context.Background(), cfg)
+	if !errors.Is(err, NotImplementedError) {
+		t.Fatalf("expected %v, got %v", NotImplementedError, err)
+	}
+	for _, expected := range []string{"via.go:13:2: broken does not implement Store: missing method Put", "method Get has func(string) string, wants func(string) (string, error)", "missing method Close"} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("%q is not reported: %v", expected, err)
+		}
+	}
+
+	cfg.Via = "io.ReadSeekCloser2"
+	if _, err = New(WithWriter(&bytes.Buffer{})).Generate(context.Background(), cfg); !errors.Is(err, NotFoundError) {
+		t.Fatalf("expected %v, got %v", NotFoundError, err)
+	}
+}
+
+func TestRename(t *testing.T) {
+	cfg := Config{
+		Package:      "github.com/alh1m1k/gosingl/test/rename",
+		Target:       "conn",
+		Comment:      "Code generated by <git repo>. DO NOT EDIT.",
+		Rename:       "Open=Connect,resource.Name=Label",
+		NameTemplate: "{{.Origin}}{{.Name}}",
+	}
+	expected, err := os.ReadFile("../test/rename/conn_singleton.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := &bytes.Buffer{}
+	result, err := New(WithWriter(b)).Generate(context.Background(), cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.String() != string(expected) {
+		t.Fatalf("result is not the same as expected : %s", diff(b.String(), string(expected), 10))
+	}
+	//checker compares renamed functions, both Close are generated
+	if len(result.Diagnostics) != 0 {
+		t.Fatalf("unexpected diagnostics: %v", result.Diagnostics)
+	}
+
+	//interface declares the method set of the target, ambiguous Close is proxied by the facade only
+	api := cfg
+	api.API, api.Verify = true, true
+	b = &bytes.Buffer{}
+	if _, err = New(WithWriter(b)).Generate(context.Background(), api); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(b.String(), "type connAPI interface {\n\tOpen() error\n\tName() string\n}") {
+		t.Fatalf("interface is not the method set of the target: %s", b.String())
+	}
+	//fake stands in for the target, it is not renamed
+	fake := cfg
+	fake.Mode, fake.Verify = FakeMode, true
+	b = &bytes.Buffer{}
+	if _, err = New(WithWriter(b)).Generate(context.Background(), fake); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(b.String(), "func (fake *FakeConn) Open() (r0 error)") || strings.Count(b.String(), "CloseCalls []") != 1 ||
+		strings.Contains(b.String(), "Connect()") || strings.Contains(b.String(), "ResourceClose") {
+		t.Fatalf("fake methods are renamed: %s", b.String())
+	}
+
+	cfg = Config{Package: cfg.Package, Target: cfg.Target, NamePrefix: "Conn", NameSuffix: "Func", Rename: "Name=Label"}
+	b = &bytes.Buffer{}
+	if _, err = New(WithWriter(b)).Generate(context.Background(), cfg); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(b.String(), "func ConnOpenFunc() error {\n\treturn Instance.Open()") || !strings.Contains(b.String(), "func Label() string") {
+		t.Fatalf("functions are not renamed: %s", b.String())
+	}
+	//same name: method implements the interface method
+	if !strings.Contains(b.String(), "func ConnCloseFunc() error {\n\treturn Instance.resource.Close()") || strings.Contains(b.String(), "Instance.Closer.Close()") {
+		t.Fatalf("implemented Close is expected once: %s", b.String())
+	}
+
+	//tail of the embedding path selects the method
+	deep := Config{Package: "github.com/alh1m1k/gosingl/test/deep", Target: "deep", Rename: "tl2.SectionReader.ReadAt=ReadSection"}
+	b = &bytes.Buffer{}
+	if _, err = New(WithWriter(b)).Generate(context.Background(), deep); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(b.String(), "func ReadSection(p []byte, off int64) (n int, err error) {\n\treturn Instance.tl1.tl2.SectionReader.ReadAt(p, off)") {
+		t.Fatalf("function is not renamed: %s", b.String())
+	}
+
+	for _, cfg := range []Config{
+		{Package: cfg.Package, Target: cfg.Target, Rename: "Open"},
+		{Package: cfg.Package, Target: cfg.Target, Rename: "Open=Open Conn"},
+		{Package: cfg.Package, Target: cfg.Target, NameTemplate: "{{.Receiver}}{{.Name}}"},
+		{Package: cfg.Package, Target: cfg.Target, NamePrefix: "1"},
+	} {
+		if _, err = New(WithWriter(&bytes.Buffer{})).Generate(context.Background(), cfg); err == nil {
+			t.Errorf("malformed rename is accepted: %+v", cfg)
+		}
+	}
+}
+
+func BenchmarkDeep(b *testing.B) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+	for _, jobs := range []int{1, 4, 0} {
+		b.Run(fmt.Sprintf("jobs=%d", jobs), func(b *testing.B) {
+			cfg := Config{Package: "github.com/alh1m1k/gosingl/test/sectionReader", Target: "sectionReader", Jobs: jobs}
+			for i := 0; i < b.N; i++ {
+				if _, err := New(WithWriter(io.Discard)).Generate(context.Background(), cfg); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -1,4 +1,4 @@
-package main
+package generator
 
 import (
 	"context"
@@ -14,18 +14,39 @@ import (
 
 var ParserWarning = errors.New("WARNING:")
 
-type loaderCallback func(ctx context.Context, cfg Config) error
+// loaderCallback schedules the embedded member, parent is the position of the task which embeds it
+type loaderCallback func(ctx context.Context, parent []int, cfg Config) error
 
 type wrappedFunctionDeclaration struct {
-	Name        string
-	Content     []*jen.Statement
-	IsInterface bool
-	Signature   types.Object
+	Name            string //name of the generated function
+	Method          string //name of the proxied method, it differs from Name if function is renamed
+	Content         []*jen.Statement
+	IsInterface     bool
+	IsField         bool //function is a field of the structure, not a method
+	PointerReceiver bool //method is declared with the pointer receiver
+	Signature       types.Object
+	Params, Results *ast.FieldList
+	CallPrefix      []string   //path of embedded members from the target to the function
+	origin          *generator //generator state at the moment of wrapping, used to rebuild signature
 	Config
 }
 
+// signature resolved by the type check, nil if it is unknown: package has errors or the declaration is out of
+// the checked files. checks which need the types pass such function, generated code is checked by the compiler
+// (or by --verify) anyway, so an unresolved function is reported there instead of being dropped silently
+func (fn *wrappedFunctionDeclaration) signature() *types.Signature {
+	if fn.Signature == nil {
+		return nil
+	}
+	signature, _ := fn.Signature.Type().(*types.Signature)
+	return signature
+}
+
 // generator will work on the selected Structure of one file
 type generator struct {
+	run             *run
+	order           []int //position of the task in the embedding tree
+	leaf            bool  //embedded members are not walked
 	defs            packageDefs
 	pkg             *types.Package
 	imports         []*ast.ImportSpec
@@ -33,16 +54,20 @@ type generator struct {
 	cfg             Config
 	loader          loaderCallback
 	resolver        Resolver
+	paramNamer      Namer
 	deep, generated int
 	output          []*wrappedFunctionDeclaration
 }
 
-func newGenerator(imports []*ast.ImportSpec, cfg Config, loader loaderCallback, defs packageDefs, path string) *generator {
+func newGenerator(run *run, task *pendingParserReq, imports []*ast.ImportSpec, loader loaderCallback, defs packageDefs, path string) *generator {
 	return &generator{
+		run:     run,
+		order:   task.order,
+		leaf:    task.leaf,
 		defs:    defs,
 		imports: imports,
 		path:    path,
-		cfg:     cfg,
+		cfg:     task.Config,
 		loader:  loader,
 		output:  nil,
 	}
@@ -72,11 +97,12 @@ func (g *generator) Do(ctx context.Context, target *ast.TypeSpec, targetMethods
 	}
 
 	if g.output == nil {
-		g.output = bufferFrom(ctx, g.cfg)
+		g.output = []*wrappedFunctionDeclaration{}
 	}
 
 	//chaining resolvers
 	g.resolver = resolverFrom(ctx).NewResolver()
+	g.paramNamer = g.run.namer
 
 	//todo struct or bitmap
 	g.deep = deepFrom(ctx)
@@ -85,7 +111,11 @@ func (g *generator) Do(ctx context.Context, target *ast.TypeSpec, targetMethods
 		switch method := targetMethods[i].(type) {
 		case *ast.FuncDecl:
 			if method.Name.IsExported() /*&& g.checker.Check(method.Name.Name, method.Type.Params, method.Type.Results, g.interfaceWalk, g.cfg)*/ {
-				g.output = append(g.output, g.wrapFunction(ctx, method.Name, method.Type.Params, method.Type.Results, method.Doc.Text()))
+				decl := g.wrapFunction(ctx, method.Name, method.Type.Params, method.Type.Results, method.Doc.Text())
+				if method.Recv != nil && len(method.Recv.List) > 0 {
+					_, decl.PointerReceiver = method.Recv.List[0].Type.(*ast.StarExpr)
+				}
+				g.output = append(g.output, decl)
 			}
 		default:
 			log.Println("ubnormal value in targetMethods", reflect.ValueOf(targetMethods[i]).String())
@@ -96,8 +126,8 @@ func (g *generator) Do(ctx context.Context, target *ast.TypeSpec, targetMethods
 		switch structure := target.Type.(type) {
 		case *ast.StructType:
 			for _, field := range structure.Fields.List {
-				if g.isIgnored(field.Tag) {
-					continue
+				if g.isIgnored(field.Tag) || (g.leaf && len(field.Names) == 0) {
+					continue //embedded members of the leaf are not walked
 				}
 				if err := g.digField(ctx, field, field.Type); err != nil {
 					if errors.Is(err, ParserWarning) {
@@ -111,7 +141,7 @@ func (g *generator) Do(ctx context.Context, target *ast.TypeSpec, targetMethods
 		case *ast.InterfaceType:
 			ctx = withInterfaceWalk(ctx)
 			for _, field := range structure.Methods.List {
-				if g.isIgnored(field.Tag) {
+				if g.isIgnored(field.Tag) || (g.leaf && len(field.Names) == 0) {
 					continue
 				}
 				if err := g.digField(ctx, field, field.Type); err != nil {
@@ -150,7 +180,9 @@ func (g *generator) digField(ctx context.Context, field *ast.Field, inner ast.Ex
 	case *ast.FuncType:
 		for i := range field.Names {
 			if field.Names[i].IsExported() && len(field.Names[i].Name) > 0 {
-				g.output = append(g.output, g.wrapFunction(ctx, field.Names[i], fieldTyped.Params, fieldTyped.Results, field.Doc.Text()))
+				decl := g.wrapFunction(ctx, field.Names[i], fieldTyped.Params, fieldTyped.Results, field.Doc.Text())
+				decl.IsField = !interfaceWalkFrom(ctx) //interface method spec is not a field
+				g.output = append(g.output, decl)
 			}
 		}
 	case *ast.StarExpr:
@@ -265,7 +297,7 @@ func (g *generator) parsePackage(ctx context.Context, pkg, structure, comment st
 	cfg.Target = structure
 	cfg.Comment = comment
 
-	err := g.loader(ctx, cfg)
+	err := g.loader(ctx, g.order, cfg)
 	if err != nil {
 		return err
 	}
@@ -286,7 +318,8 @@ func (g *generator) isIgnored(tag *ast.BasicLit) bool {
 func (g *generator) wrapFunction(ctx context.Context, ident *ast.Ident, in, out *ast.FieldList, comment string) *wrappedFunctionDeclaration {
 
 	decl := &wrappedFunctionDeclaration{
-		Name:        ident.Name,
+		Name:        g.run.renamer.Rename(ident.Name, callPrefixFrom(ctx), g.cfg),
+		Method:      ident.Name,
 		Content:     make([]*jen.Statement, 0),
 		IsInterface: false,
 		Signature:   nil,
@@ -297,8 +330,8 @@ func (g *generator) wrapFunction(ctx context.Context, ident *ast.Ident, in, out
 		decl.Content = append(decl.Content, jen.Comment(comment))
 	}
 
-	namer := namerFrom(ctx).NewNamer() //refresh namer for every 1 level function
-	fnBuilder := jen.Add(g.buildFunction(ident.Name, in, out, namer, false))
+	namer := g.namer() //refresh namer for every 1 level function
+	fnBuilder := jen.Add(g.buildFunction(decl.Name, in, out, namer, false))
 	underFn := jen.Id(g.cfg.Variable)
 
 	//prefix is callContext ie Instance.[callCtx[0]...callCtx[n]].DoSome()
@@ -337,10 +370,28 @@ func (g *generator) wrapFunction(ctx context.Context, ident *ast.Ident, in, out
 	decl.Content = append(decl.Content, fnBuilder)
 	decl.IsInterface = interfaceWalkFrom(ctx)
 	decl.Signature = g.defs[ident]
+	decl.Params, decl.Results = in, out
+	decl.CallPrefix = append([]string{}, callPrefixFrom(ctx)...)
+	decl.origin = g.snapshot()
 
 	return decl
 }
 
+// namer fresh namer for the one function
+func (g *generator) namer() Namer {
+	if g.paramNamer == nil {
+		return newParameterNamer()
+	}
+	return g.paramNamer.NewNamer()
+}
+
+// snapshot copy generator state (resolver, imports) in order to build declaration of the function later
+func (g *generator) snapshot() *generator {
+	snapshot := *g
+	snapshot.output = nil
+	return &snapshot
+}
+
 func (g *generator) addFnParam(fnGroup *jen.Group, field *ast.Field, name string) {
 	if _, ok := field.Type.(*ast.Ellipsis); ok {
 		fnGroup.Add(jen.Id(name).Op("..."))
@@ -414,6 +465,46 @@ func (g *generator) buildParams(params *ast.FieldList, namer Namer, buildSignatu
 	return result
 }
 
+// namedParam is a parameter of the function with name resolved by Namer
+type namedParam struct {
+	Name  string
+	Field *ast.Field
+}
+
+func (p namedParam) IsVariadic() bool {
+	_, ok := p.Field.Type.(*ast.Ellipsis)
+	return ok
+}
+
+// namedParams flatten field list into parameters, anonymous and _ parameters are named the same way as buildParams does
+func namedParams(params *ast.FieldList, namer Namer, typeOf string) []namedParam {
+	var result []namedParam
+	if params == nil {
+		return result
+	}
+	for _, field := range params.List {
+		for _, fieldIdent := range field.Names {
+			fieldName := fieldIdent.Name
+			if fieldName == "" || fieldName == "_" {
+				fieldName = namer.NewName(typeOf)
+			}
+			result = append(result, namedParam{Name: fieldName, Field: field})
+		}
+		if len(field.Names) == 0 {
+			result = append(result, namedParam{Name: namer.NewName(typeOf), Field: field})
+		}
+	}
+	return result
+}
+
+// buildValueType build type of the parameter, variadic parameter became slice
+func (g *generator) buildValueType(param namedParam) *jen.Statement {
+	if ellipsis, ok := param.Field.Type.(*ast.Ellipsis); ok {
+		return g.recursBuildParam(ellipsis.Elt, jen.Index())
+	}
+	return g.recursBuildParam(param.Field.Type, &jen.Statement{})
+}
+
 func (g *generator) recursBuildParam(param ast.Expr, root *jen.Statement) *jen.Statement {
 	switch exp := param.(type) {
 	case *ast.StarExpr:
@@ -569,22 +660,6 @@ func tagToMap(lit *ast.BasicLit) map[string]string {
 	return result
 }
 
-func namerFrom(ctx context.Context) Namer {
-	if namer, ok := ctx.Value("namer").(Namer); !ok || namer == nil {
-		namer = newParameterNamer()
-		return namer
-	} else {
-		return namer
-	}
-}
-
-func bufferFrom(ctx context.Context, cfg Config) []*wrappedFunctionDeclaration {
-	if buffer, ok := ctx.Value("buffer").([]*wrappedFunctionDeclaration); ok && buffer != nil {
-		return buffer
-	}
-	return []*wrappedFunctionDeclaration{}
-}
-
 func interfaceWalkFrom(ctx context.Context) bool {
 	if _markInterfaceWalk, ok := ctx.Value("_markInterfaceWalk").(bool); ok {
 		return _markInterfaceWalk
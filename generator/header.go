@@ -0,0 +1,276 @@
+package generator
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Version of the generator recorded in the header of the generated file
+const Version = "0.2.0"
+
+// headerPrefix marks the line with generation parameters of the file
+const headerPrefix = "//gosingl:config "
+
+var HeaderError = errors.New("malformed generation header")
+
+// generatedComment standard comment of the generated file, see go help generate
+var generatedComment = regexp.MustCompile(`^// Code generated .* DO NOT EDIT\.$`)
+
+// generationHeader parameters which reproduce the generated file
+type generationHeader struct {
+	Version    string `json:"version"`
+	Package    string `json:"pkg"`
+	Target     string `json:"target"`
+	Variable   string `json:"variable,omitempty"`
+	Deep       int    `json:"deep,omitempty"`
+	Suffix     string `json:"suffix,omitempty"`
+	Mode       string `json:"mode,omitempty"`
+	API        bool   `json:"api,omitempty"`
+	Include    string `json:"include,omitempty"`
+	Exclude    string `json:"exclude,omitempty"`
+	OutPackage string `json:"outPkg,omitempty"`
+	Strict     bool   `json:"strict,omitempty"`
+	Files      string `json:"files,omitempty"`
+	SkipFiles  string `json:"skipFiles,omitempty"`
+	Descend    string `json:"descend,omitempty"`
+	StopAt     string `json:"stopAt,omitempty"`
+	Leaf       string `json:"leaf,omitempty"`
+	From       string `json:"from,omitempty"`
+	SkipFrom   string `json:"skipFrom,omitempty"`
+	Receiver   string `json:"receiver,omitempty"`
+	Via        string `json:"via,omitempty"`
+	NamePrefix string `json:"namePrefix,omitempty"`
+	NameSuffix string `json:"nameSuffix,omitempty"`
+	Rename     string `json:"rename,omitempty"`
+	NameTpl    string `json:"nameTemplate,omitempty"`
+}
+
+// headerLine machine-readable line with the generation parameters
+func headerLine(cfg Config) string {
+	buffer := &bytes.Buffer{}
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	_ = encoder.Encode(generationHeader{
+		Version:    Version,
+		Package:    cfg.Package,
+		Target:     cfg.Target,
+		Variable:   cfg.Variable,
+		Deep:       cfg.Deep,
+		Suffix:     cfg.Suffix,
+		Mode:       cfg.Mode,
+		API:        cfg.API,
+		Include:    cfg.Include,
+		Exclude:    cfg.Exclude,
+		OutPackage: cfg.OutPackage,
+		Strict:     cfg.Strict,
+		Files:      cfg.IncludeFiles,
+		SkipFiles:  cfg.ExcludeFiles,
+		Descend:    cfg.Descend,
+		StopAt:     cfg.StopAt,
+		Leaf:       cfg.Leaf,
+		From:       cfg.IncludeFrom,
+		SkipFrom:   cfg.ExcludeFrom,
+		Receiver:   cfg.Receiver,
+		Via:        cfg.Via,
+		NamePrefix: cfg.NamePrefix,
+		NameSuffix: cfg.NameSuffix,
+		Rename:     cfg.Rename,
+		NameTpl:    cfg.NameTemplate,
+	})
+	return headerPrefix + strings.TrimSpace(buffer.String())
+}
+
+// readHeader restores config of the generated file, file comment is the comment above the package clause,
+// false if file has no header
+func readHeader(path string) (Config, bool, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return Config{}, false, err
+	}
+	defer file.Close()
+
+	var (
+		comment []string
+		header  *generationHeader
+	)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.HasPrefix(line, headerPrefix):
+			header = &generationHeader{}
+			if err = json.Unmarshal([]byte(strings.TrimPrefix(line, headerPrefix)), header); err != nil {
+				return Config{}, false, fmt.Errorf("%s: %w: %s", path, HeaderError, err)
+			}
+			continue
+		case line == "":
+			comment = comment[:0] //comment is separated from the package clause
+			continue
+		case strings.HasPrefix(line, "//"):
+			comment = append(comment, strings.TrimSpace(strings.TrimPrefix(line, "//")))
+			continue
+		}
+		break //header is placed above the package clause only
+	}
+	if err = scanner.Err(); err != nil || header == nil {
+		return Config{}, false, err
+	}
+	return Config{
+		Deep:         header.Deep,
+		Package:      header.Package,
+		Target:       header.Target,
+		Variable:     header.Variable,
+		Comment:      strings.Join(comment, "\n"),
+		Suffix:       header.Suffix,
+		Mode:         header.Mode,
+		API:          header.API,
+		Include:      header.Include,
+		Exclude:      header.Exclude,
+		OutPackage:   header.OutPackage,
+		Strict:       header.Strict,
+		IncludeFiles: header.Files,
+		ExcludeFiles: header.SkipFiles,
+		Descend:      header.Descend,
+		StopAt:       header.StopAt,
+		Leaf:         header.Leaf,
+		IncludeFrom:  header.From,
+		ExcludeFrom:  header.SkipFrom,
+		Receiver:     header.Receiver,
+		Via:          header.Via,
+		NamePrefix:   header.NamePrefix,
+		NameSuffix:   header.NameSuffix,
+		Rename:       header.Rename,
+		NameTemplate: header.NameTpl,
+	}, true, nil
+}
+
+// isOutput reports whether the file is generated by gosingl, whatever the name of the file: it has the header of
+// the generation or the standard generated comment is the package comment, as gosingl writes the --comment.
+// other generators separate the comment from the package clause, their files are the input
+func isOutput(path string) bool {
+	file, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+
+	generated := false
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.HasPrefix(line, headerPrefix):
+			return true
+		case line == "":
+			generated = false //comment is detached from the package clause
+		case strings.HasPrefix(line, "//"):
+			generated = generated || generatedComment.MatchString(line)
+		default:
+			return generated //package clause
+		}
+	}
+	return false
+}
+
+// sourceFiles selects files of the package which are the input of the generation, file names are matched with
+// include and exclude globs of the config. outputs of the gosingl are looked for in the target package only,
+// other packages are not scanned
+func sourceFiles(cfg Config, target bool) fileFilter {
+	return func(path string) bool {
+		name := filepath.Base(path)
+		if cfg.IncludeFiles != "" && !matchGlobs(cfg.IncludeFiles, name) {
+			return false
+		}
+		if cfg.ExcludeFiles != "" && matchGlobs(cfg.ExcludeFiles, name) {
+			return false
+		}
+		return !target || !isOutput(path)
+	}
+}
+
+// matchGlobs name matches one of the comma separated globs
+func matchGlobs(globs, name string) bool {
+	for _, glob := range strings.Split(globs, ",") {
+		if ok, _ := filepath.Match(strings.TrimSpace(glob), name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// generatedFiles finds files with the header, pattern is a directory, "dir/..." includes subdirectories
+func generatedFiles(pattern string) ([]string, error) {
+	dirs, err := patternDirs(pattern)
+	if err != nil {
+		return nil, err
+	}
+	var result []string
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			path := filepath.Join(dir, entry.Name())
+			if entry.IsDir() || !strings.HasSuffix(path, ".go") {
+				continue
+			}
+			if _, ok, err := readHeader(path); err != nil {
+				return nil, err
+			} else if ok {
+				result = append(result, path)
+			}
+		}
+	}
+	return result, nil
+}
+
+// Regenerate re-runs generation of every file with the header, only changed files are rewritten
+func (g *Generator) Regenerate(ctx context.Context, patterns []string) error {
+	for _, pattern := range patterns {
+		files, err := generatedFiles(pattern)
+		if err != nil {
+			return err
+		}
+		for _, path := range files {
+			cfg, _, err := readHeader(path)
+			if err != nil {
+				return err
+			}
+			if cfg.Path, err = filepath.Abs(path); err != nil {
+				return err
+			}
+			cfg.Write = true
+			info("regenerate", path)
+			if _, err = g.Generate(ctx, cfg); err != nil {
+				return fmt.Errorf("%s: %w", path, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Clean removes every file with the header
+func Clean(patterns []string) error {
+	for _, pattern := range patterns {
+		files, err := generatedFiles(pattern)
+		if err != nil {
+			return err
+		}
+		for _, path := range files {
+			info("remove", path)
+			if err = os.Remove(path); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package generator
+
+import (
+	"context"
+	"io"
+)
+
+// Function declaration of the generated function, see Checker
+type Function = wrappedFunctionDeclaration
+
+// Result of the generation
+type Result struct {
+	Path        string   //written file, empty if code is written to the writer or printed
+	Code        []byte   //rendered code, empty if generation is skipped because inputs are not changed
+	Written     bool     //file is written, unchanged file is kept untouched
+	Diagnostics []string //functions which are skipped or dropped by the checker
+	Inputs      []string //directories of the packages which are read during the generation
+	Explain     []string //traversal rules applied to the embedded members, see Config.Explain
+}
+
+// Generator generates code of the Config, zero options give the same result as the command line tool
+type Generator struct {
+	writer    io.Writer
+	namer     Namer
+	checker   Checker
+	filter    fileFilter
+	blackList []string      //suffixes of the files which are not parsed, see WithBlackList
+	records   loaderRecords //parsed packages shared with the other generations, see generateTargets
+}
+
+type Option func(g *Generator)
+
+// WithWriter generated code is written into the writer, Config.Write is ignored
+func WithWriter(writer io.Writer) Option {
+	return func(g *Generator) {
+		g.writer = writer
+	}
+}
+
+// WithNamer generates names of the unnamed function parameters
+func WithNamer(namer Namer) Option {
+	return func(g *Generator) {
+		g.namer = namer
+	}
+}
+
+// WithChecker excludes duplicates and ambiguous functions from the output
+func WithChecker(checker Checker) Option {
+	return func(g *Generator) {
+		g.checker = checker
+	}
+}
+
+// WithFileFilter selects files of every parsed package, filter is applied in addition to the Config file globs
+func WithFileFilter(filter func(path string) bool) Option {
+	return func(g *Generator) {
+		g.filter = filter
+	}
+}
+
+// WithBlackList suffixes of the files which are never parsed, "_test.go" by default
+func WithBlackList(suffixes ...string) Option {
+	return func(g *Generator) {
+		g.blackList = suffixes
+	}
+}
+
+func New(options ...Option) *Generator {
+	g := &Generator{}
+	for _, option := range options {
+		option(g)
+	}
+	return g
+}
+
+// Generate generates code of the config, result holds rendered code even if generation fails on verification
+func (g *Generator) Generate(ctx context.Context, cfg Config) (Result, error) {
+	result := &Result{}
+	err := generatePackage(ctx, newRun(g, result), cfg)
+	return *result, err
+}
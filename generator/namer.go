@@ -1,4 +1,4 @@
-package main
+package generator
 
 import (
 	"fmt"
@@ -72,3 +72,19 @@ func newParameterNamer() *parameterNamer {
 	n.Reset()
 	return n
 }
+
+// freeName base if none of the parameters is named so, otherwise the first name of the namer which is not taken.
+// generated bodies declare receivers and locals next to the parameters of the proxied signature
+func freeName(namer Namer, base string, params ...[]namedParam) string {
+	taken := map[string]bool{}
+	for _, list := range params {
+		for _, param := range list {
+			taken[param.Name] = true
+		}
+	}
+	name := base
+	for taken[name] {
+		name = namer.NewName(base)
+	}
+	return name
+}
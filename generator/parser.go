@@ -0,0 +1,808 @@
+package generator
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/dave/jennifer/jen"
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var (
+	ProcessedError = errors.New("target processed")
+	NotFoundError  = errors.New("not found")
+)
+
+type vType uint
+
+const (
+	Auto vType = iota
+	Real
+	Ref
+)
+
+// generation modes, every mode produce own file
+const (
+	FacadeMode    = ""
+	FakeMode      = "fake"
+	TestMode      = "test"
+	RPCMode       = "rpc"
+	RPCClientMode = "rpc-client"
+	ReexportMode  = "reexport"
+)
+
+var modeSuffix = map[string]string{
+	FacadeMode:    "_singleton.go",
+	FakeMode:      "_singleton_fake.go",
+	TestMode:      "_singleton_test.go",
+	RPCMode:       "_singleton_rpc.go",
+	RPCClientMode: "_singleton_rpc_client.go",
+	ReexportMode:  "_reexport.go",
+}
+
+type Config struct {
+	Deep         int
+	Package      string
+	Target       string
+	Variable     string
+	Comment      string
+	Write        bool
+	Suffix, Path string
+	API          bool   //declare interface of the facade
+	Mode         string //what to generate, facade by default
+	Include      string //regexp of the names to generate, all by default
+	Exclude      string //regexp of the names to skip
+	OutPackage   string //package of the generated file, package of the target by default
+	Strict       bool   //fail instead of skip function which may not be generated
+	Verify       bool   //type-check generated code before write
+	Check, Diff  bool   //compare generated code with the file instead of write, check fails if they differ
+	Force        bool   //generate even if inputs are not changed
+	IncludeFiles string //comma separated globs of the file names to parse, all by default
+	ExcludeFiles string //comma separated globs of the file names to skip
+	Jobs         int    //number of the packages processed in parallel, GOMAXPROCS by default
+	Descend      string //comma separated patterns of the packages whose embedded members are walked, all by default
+	StopAt       string //comma separated patterns of the packages whose embedded members are not walked
+	Leaf         string //what embedded member of the stopped package contributes, own methods by default
+	Explain      bool   //report the traversal rules applied to the embedded members
+	IncludeFrom  string //comma separated origins of the functions to generate: embedded fields path or declaring type
+	ExcludeFrom  string //comma separated origins of the functions to skip
+	Receiver     string //receiver of the methods to generate: value or pointer, both by default
+	Via          string //interface (pkg.Interface) whose method set is generated, target must implement it
+	NamePrefix   string //prefix of the names of the generated functions
+	NameSuffix   string //suffix of the names of the generated functions
+	Rename       string //comma separated Old=New names of the generated functions, Old may be qualified by the embedding path
+	NameTemplate string //text/template of the names of the generated functions, {{.Origin}}{{.Name}} for example
+}
+
+type loaderRecord struct {
+	*types.Package
+	packageDefs
+	files   map[string]*ast.File
+	fileSet *token.FileSet
+	path    string
+	inited  bool
+	sync.Mutex
+}
+type loaderRecords map[string]*loaderRecord
+
+// defaultBlackList suffixes of the files which are never parsed
+var defaultBlackList = []string{"_test.go"}
+
+type pendingParserReq struct {
+	Config
+	context.Context
+	order []int //position in the embedding tree, see run.child
+	leaf  bool  //embedded members of the target are not walked, see Config.Leaf
+}
+
+type packageDefs map[*ast.Ident]types.Object
+
+// ParsePackage launch the generation with the default options, see New for the others
+func ParsePackage(ctx context.Context, cfg Config) error {
+	return generatePackage(ctx, newRun(New(), nil), cfg)
+}
+
+// generatePackage launch the generation, options of the generation are kept by the run
+func generatePackage(ctx context.Context, run *run, cfg Config) (err error) {
+
+	var (
+		totalGenerated []*wrappedFunctionDeclaration
+	)
+	if err = ctx.Err(); err != nil {
+		return err
+	}
+	defer func() {
+		run.result.Inputs = run.collectedDirs()
+	}()
+
+	cfg.Package = strings.TrimSpace(cfg.Package)
+	cfg.Target = strings.TrimSpace(cfg.Target)
+	cfg.Variable = strings.TrimSpace(cfg.Variable)
+	cfg.Suffix = strings.TrimSpace(cfg.Suffix)
+	cfg.Path = strings.TrimSpace(cfg.Path)
+	cfg.Comment = strings.TrimSpace(cfg.Comment)
+	cfg.Mode = strings.TrimSpace(cfg.Mode)
+	cfg.OutPackage = strings.TrimSpace(cfg.OutPackage)
+	cfg.Leaf = strings.TrimSpace(cfg.Leaf)
+	cfg.Via = strings.TrimSpace(cfg.Via)
+
+	if len(cfg.Package) == 0 {
+		return errors.New("no directory submitted")
+	}
+
+	if len(cfg.Target) == 0 {
+		return errors.New("no target submitted")
+	}
+
+	if _, ok := modeSuffix[cfg.Mode]; !ok {
+		return fmt.Errorf("unknown mode %s", cfg.Mode)
+	}
+
+	if len(cfg.Variable) == 0 && cfg.Mode == RPCClientMode {
+		cfg.Variable = DefaultClientVariable
+	}
+
+	if len(cfg.Variable) == 0 {
+		cfg.Variable = "Instance"
+	}
+
+	if len(cfg.Suffix) == 0 {
+		cfg.Suffix = "_singleton.go"
+	}
+
+	if run.writer != nil || len(cfg.Path) > 0 || cfg.Suffix != "_singleton.go" {
+		cfg.Write = true
+	}
+
+	cfg.Suffix = outputSuffix(cfg)
+
+	rules, err := newDescentRules(cfg)
+	if err != nil {
+		return err
+	}
+	filter, err := newFunctionFilter(cfg)
+	if err != nil {
+		return err
+	}
+	renamer, err := newRenamer(cfg)
+	if err != nil {
+		return err
+	}
+
+	if cacheable(run, cfg) {
+		if upToDate(cfg) {
+			run.result.Path, _ = outputPath(cfg) //resolved by the fingerprint
+			info(run.result.Path, "is up to date, inputs are not changed")
+			return nil
+		}
+		inputs := cfg //config is modified during the generation
+		defer func() {
+			if err == nil {
+				storeFingerprint(inputs, run.collectedDirs())
+			}
+		}()
+	}
+
+	if cfg.Mode == ReexportMode {
+		return reexportPackage(run, cfg)
+	}
+
+	// get the path of the package
+	if strings.TrimSpace(os.Getenv("GOPATH")) == "" {
+		caution("WARNING: OS ENV GOPATH NOT SET!")
+	}
+	if strings.TrimSpace(os.Getenv("GOROOT")) == "" {
+		caution("WARNING: OS ENV GOROOT NOT SET!")
+	}
+
+	info("parse package", cfg.Package, cfg.Target)
+	run.target = cfg.Package
+
+	if outputPackage(cfg) != cfg.Package {
+		//local types are qualified by the package of the target
+		if err = checkOutPackage(cfg); err != nil {
+			return err
+		}
+	}
+
+	header := headerLine(cfg) //before variable declaration is cleared
+	buffer := jen.NewFilePathName(outputPackage(cfg), packageName(outputPackage(cfg)))
+	//loader := recursiveLoaderBuilder(buffer, cfg)
+	loader := linearLoaderBuilder(run, buffer, cfg)
+
+	varDecl := newVariableDeclFromConfig(cfg)
+	cfg.Variable = clearVarDeclaration(cfg.Variable)
+
+	buffer.HeaderComment(header)
+	if len(cfg.Comment) > 0 {
+		buffer.PackageComment(cfg.Comment)
+		buffer.Line()
+	}
+
+	//variable placeholder will be updated later
+	if cfg.Mode == FacadeMode {
+		buffer.Var().Add(varDecl.Declare())
+	}
+	ctx = withPending(ctx, []Delayed{varDecl})
+	run.renamer = renamer
+	ctx = withResolver(ctx, varDecl.rootResolver)
+
+	cfg.Comment = fmt.Sprintf("<%s>", cfg.Target)
+	root := cfg
+	if cfg.Via != "" {
+		//proxies are generated from the interface declaration, method set of the interface is walked completely
+		if root.Package, root.Target, err = checkVia(ctx, run, cfg); err != nil {
+			return err
+		}
+		root.Comment, root.Deep, rules = fmt.Sprintf("<%s>", cfg.Via), 0, &descentRules{leaf: LeafMethods}
+	}
+	result := runTasks(ctx, run, loader, []*pendingParserReq{{Config: root, Context: ctx}}, 1)[0]
+	if result.error != nil {
+		return result.error
+	}
+	ctx = result.Context
+
+	totalGenerated = append(totalGenerated, result.Decl...)
+
+	//generate dep tree packages begin, level by level: tasks of the level are scheduled by the previous one
+
+	untilEnd := root.Deep == 0
+	run.explain(cfg, "%s.%s: %s", root.Package, root.Target, rules)
+	scheduled := map[string]bool{root.Package + "." + root.Target: true}
+	originalOrder := make([]*pendingParserReq, 0)
+	generatedParts := make(map[string][]*wrappedFunctionDeclaration, 0)
+	for level := run.takePending(); len(level) > 0; level = run.takePending() {
+		sort.SliceStable(level, func(i, j int) bool {
+			return orderLess(level[i].order, level[j].order)
+		})
+		//member which is embedded several times is generated once, by the first in order
+		tasks := make([]*pendingParserReq, 0, len(level))
+		for _, task := range level {
+			key := task.Package + "." + task.Target
+			if !untilEnd && task.Deep <= 0 {
+				run.explain(cfg, "%s %s: skipped by --deep %d", task.Comment, key, cfg.Deep)
+				continue
+			}
+			if scheduled[key] {
+				continue
+			}
+			scheduled[key] = true
+			if rule, stopped := rules.stopped(task.Package); !stopped {
+				run.explain(cfg, "%s %s: walked", task.Comment, key)
+			} else if rules.leaf == LeafNone {
+				run.explain(cfg, "%s %s: skipped by %s", task.Comment, key, rule)
+				continue
+			} else {
+				run.explain(cfg, "%s %s: own methods only, stopped by %s", task.Comment, key, rule)
+				task.leaf = true
+			}
+			tasks = append(tasks, task)
+		}
+		originalOrder = append(originalOrder, tasks...) //keep original order
+
+		for i, result := range runTasks(ctx, run, loader, tasks, cfg.Jobs) {
+			if result.error != nil && !errors.Is(result.error, ProcessedError) {
+				info(result.error)
+			}
+			generatedParts[fmt.Sprint(tasks[i].order)] = result.Decl
+		}
+		if err = ctx.Err(); err != nil {
+			return err //canceled routine reports empty result
+		}
+	}
+
+	//restore original order: level by level, embedded members in the order of declaration
+	for _, task := range originalOrder {
+		totalGenerated = append(totalGenerated, generatedParts[fmt.Sprint(task.order)]...)
+	}
+	generatedParts, originalOrder = nil, nil
+
+	checker := run.checker.NewChecker(filter.Apply(run, cfg, totalGenerated))
+	//facade in other package must not drop anything silently
+	content, err := accessibleContent(run, cfg, checker.Valid(), cfg.Strict || outputPackage(cfg) != cfg.Package)
+	if err != nil {
+		return err
+	}
+	switch cfg.Mode {
+	case FakeMode:
+		glueFake(buffer, content, cfg)
+	case TestMode:
+		if err = glueForwardTest(run, buffer, content, varDecl, cfg); err != nil {
+			return err
+		}
+	case RPCMode:
+		if content, err = rpcContent(run, cfg, content); err != nil {
+			return err
+		}
+		glueRPC(buffer, content, varDecl, cfg)
+	case RPCClientMode:
+		if content, err = rpcContent(run, cfg, content); err != nil {
+			return err
+		}
+		glueRPCClient(buffer, content, cfg, cfg.Variable)
+	default:
+		glue(buffer, content, cfg)
+		if cfg.API {
+			methods, err := apiMethods(ctx, run, cfg)
+			if err != nil {
+				return err
+			}
+			glueAPI(buffer, content, varDecl, methods, cfg)
+		}
+	}
+
+	varDecl.CompleteResolve() //resolve all pending decl
+
+	if err = writeOutput(run, cfg, buffer, content); err != nil {
+		return err
+	}
+
+	checkerErrors := checker.Invalid() //if it not in use it usually will be empty
+	if len(checkerErrors) > 0 {
+		fmt.Println("")
+		info(fmt.Sprintf("Followed checker errors appears while parsing package %s (probably duplication of declaration) it was dropped from output", cfg.Package))
+	}
+	for _, fn := range checkerErrors {
+		run.diagnose(fmt.Sprintf("%s", fn.Signature))
+	}
+	if len(checkerErrors) > 0 {
+		fmt.Println("")
+	}
+	return nil
+}
+
+func linearLoaderBuilder(run *run, buffer *jen.File, cfg Config) loaderCallback {
+
+	var linearLoader loaderCallback
+	linearLoader = func(ctx context.Context, parent []int, cfg Config) error {
+		run.schedule(&pendingParserReq{
+			Config:  cfg,
+			Context: ctx,
+			order:   run.child(parent),
+		})
+		return nil
+	}
+
+	return linearLoader
+}
+
+func glue(output *jen.File, content []*wrappedFunctionDeclaration, cfg Config) {
+	var (
+		pkg, target string
+	)
+
+	for _, fn := range content {
+		if pkg != fn.Package && target != fn.Target {
+			output.Line().Comment(fmt.Sprintf("%s from %s", strings.TrimSpace(fn.Comment), fn.Package)).Line()
+			pkg, target = fn.Package, fn.Target
+		}
+		for _, statement := range fn.Content {
+			output.Add(statement)
+			output.Line()
+		}
+	}
+}
+
+// writeOutput renders buffer to the output, in verify mode rendered code is type-checked before,
+// in check and diff modes it is compared with the file
+func writeOutput(run *run, cfg Config, buffer *jen.File, content []*wrappedFunctionDeclaration) error {
+	rendered := &bytes.Buffer{}
+	if err := buffer.Render(rendered); err != nil {
+		return err
+	}
+	result := run.result
+	result.Code = rendered.Bytes()
+	if cfg.Verify {
+		if err := verifyOutput(run, cfg, rendered.Bytes(), content); err != nil {
+			return err
+		}
+	}
+	if cfg.Check || cfg.Diff {
+		return compareOutput(run, cfg, rendered.Bytes())
+	}
+	toFile := run.writer == nil && cfg.Write
+	if toFile {
+		path, err := outputPath(cfg)
+		if err != nil {
+			return err
+		}
+		result.Path = path
+		if previous, err := os.ReadFile(result.Path); err == nil && bytes.Equal(previous, rendered.Bytes()) {
+			info(result.Path, "is up to date")
+			return nil //keep file untouched
+		}
+	}
+
+	writer, done, fail, err := setupOutput(run, cfg)
+	if err != nil {
+		return err
+	}
+	if _, err = writer.Write(rendered.Bytes()); err != nil {
+		fail()
+		return err
+	}
+	if err = done(); err != nil {
+		return err
+	}
+	result.Written = toFile
+	return nil
+}
+
+// outputPath path of the generated file
+func outputPath(cfg Config) (string, error) {
+	if cfg.Path != "" { //todo path validation
+		return cfg.Path, nil
+	}
+	if cfg.Mode == ReexportMode {
+		//target is the package which re-exports
+		p, err := build.Default.Import(cfg.Target, ".", build.FindOnly)
+		if err != nil {
+			return "", err
+		}
+		return p.Dir + "/" + packageName(cfg.Package) + cfg.Suffix, nil
+	}
+	p, err := build.Default.Import(outputPackage(cfg), ".", build.FindOnly)
+	if err != nil {
+		return "", err
+	}
+	//resetFilePath = p.Dir + "/" + packageName(importCanon(cfg.Package)) + cfg.Suffix
+	//todo encoding of filepath
+	return p.Dir + "/" + strings.ToLower(cfg.Target[0:1]) + cfg.Target[1:] + cfg.Suffix, nil
+}
+
+func setupOutput(run *run, cfg Config) (writer io.Writer, done func() error, fail func(), err error) {
+	var (
+		tempFile *os.File
+	)
+	done = func() error { return nil }
+	fail = func() {}
+	if writer = run.writer; writer == nil {
+		if !cfg.Write {
+			writer = os.Stdout
+		} else {
+			resetFilePath, err := outputPath(cfg)
+			if err != nil {
+				return writer, done, fail, err
+			}
+			mode := os.FileMode(0644)
+			if stat, err := os.Stat(resetFilePath); err == nil {
+				mode = stat.Mode().Perm() //keep mode of the previous file
+			}
+			// write into temp file of the same directory, previous file is replaced only on success
+			tempFile, err = os.CreateTemp(filepath.Dir(resetFilePath), "."+filepath.Base(resetFilePath)+".*")
+			if err != nil {
+				return writer, done, fail, err
+			}
+			writer = tempFile
+			fail = func() {
+				tempFile.Close()
+				_ = os.Remove(tempFile.Name())
+			}
+			done = func() error {
+				if err := tempFile.Sync(); err != nil {
+					fail()
+					return err
+				}
+				if err := tempFile.Chmod(mode); err != nil {
+					fail()
+					return err
+				}
+				if err := tempFile.Close(); err != nil {
+					_ = os.Remove(tempFile.Name())
+					return err
+				}
+				if err := os.Rename(tempFile.Name(), resetFilePath); err != nil {
+					_ = os.Remove(tempFile.Name())
+					return err
+				}
+				return nil
+			}
+		}
+	}
+	return writer, done, fail, err
+}
+
+// fileFilter reports whether the file of the package must be parsed
+type fileFilter func(path string) bool
+
+func collectFiles(pkg string, blacklist []string, filters ...fileFilter) (path string, files map[string]*ast.File, fileSet *token.FileSet, err error) {
+	var (
+		packages map[string]*ast.Package
+		p        *build.Package
+	)
+
+	p, err = build.Default.Import(pkg, ".", build.FindOnly)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	path = p.Dir
+
+	fileSet = token.NewFileSet()
+	packages, err = parser.ParseDir(fileSet, path, func(info fs.FileInfo) bool {
+		for _, filter := range filters {
+			if filter != nil && !filter(filepath.Join(path, info.Name())) {
+				return false
+			}
+		}
+		return true
+	}, 0)
+
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	files = make(map[string]*ast.File)
+	for pPath := range packages {
+		for j := range packages[pPath].Files {
+			if !isBlackListed(j, blacklist) {
+				files[j] = packages[pPath].Files[j]
+			} else {
+				//log.Println(j, "ignored")
+			}
+		}
+	}
+
+	return path, files, fileSet, nil
+}
+
+// routineResult generated part of the embedding tree
+type routineResult = struct {
+	context.Context
+	Decl   []*wrappedFunctionDeclaration
+	Config //in order to identify part
+	error
+}
+
+// runTasks processes tasks by the pool of the workers, jobs is the size of the pool (GOMAXPROCS by default),
+// results are in the order of the tasks
+func runTasks(ctx context.Context, run *run, loader loaderCallback, tasks []*pendingParserReq, jobs int) []routineResult {
+	results := make([]routineResult, len(tasks))
+	if jobs <= 0 {
+		jobs = runtime.GOMAXPROCS(0)
+	}
+	queue := make(chan int)
+	group := sync.WaitGroup{}
+	for worker := 0; worker < jobs && worker < len(tasks); worker++ {
+		group.Add(1)
+		go func() {
+			defer group.Done()
+			output := make(chan routineResult, 1)
+			for i := range queue {
+				task := tasks[i]
+				generateRoutine(task.Context, run, task, loader, output)
+				select {
+				case results[i] = <-output:
+				default: //canceled routine may drop the result
+					results[i] = routineResult{Context: task.Context, Config: task.Config, error: ctx.Err()}
+				}
+			}
+		}()
+	}
+schedule:
+	for i := range tasks {
+		select {
+		case queue <- i:
+		case <-ctx.Done():
+			for ; i < len(tasks); i++ {
+				results[i] = routineResult{Context: tasks[i].Context, Config: tasks[i].Config, error: ctx.Err()}
+			}
+			break schedule
+		}
+	}
+	close(queue)
+	group.Wait()
+	return results
+}
+
+func generateRoutine(ctx context.Context, run *run, task *pendingParserReq, loader loaderCallback, output chan<- routineResult) {
+	var (
+		cfg = task.Config
+		err error
+	)
+	send := func(result routineResult) {
+		select {
+		case output <- result:
+		case <-ctx.Done(): //nobody waits for the result
+		}
+	}
+	fail := func(err error) {
+		send(routineResult{Context: ctx, Decl: []*wrappedFunctionDeclaration{}, Config: cfg, error: err})
+	}
+
+	p, err := loadRecord(ctx, run, cfg)
+	if err != nil {
+		fail(err)
+		return
+	}
+	if !run.process(cfg.Package, cfg.Target) {
+		fail(ProcessedError)
+		return
+	}
+
+	indexes := make([]string, 0, len(p.files))
+	for path := range p.files {
+		indexes = append(indexes, path)
+	}
+	sort.Strings(indexes)
+	generatedTotal := make([]*wrappedFunctionDeclaration, 0)
+	targetFound := false
+	for _, file := range indexes {
+		if err = ctx.Err(); err != nil {
+			fail(err)
+			return
+		}
+		sf := newStructFinder(cfg.Target, cfg.Package)
+		ast.Inspect(p.files[file], sf.Find)
+		if sf.Structure() != nil || len(sf.Methods()) > 0 {
+			gen := newGenerator(run, task, sf.Imports(), loader, p.packageDefs, p.path)
+			if ctx, err = gen.Do(ctx, sf.Structure(), sf.Methods()); err != nil {
+				info(err)
+				continue
+			}
+			generatedTotal = append(generatedTotal, gen.Result()...)
+			if sf.Structure() != nil {
+				targetFound = true
+			}
+		}
+	}
+
+	if targetFound {
+		send(routineResult{Context: ctx, Decl: generatedTotal, Config: cfg, error: nil})
+	} else {
+		fail(fmt.Errorf("%s: %s %w", cfg.Package, cfg.Target, NotFoundError))
+	}
+
+	return
+}
+
+// loadRecord parses and type-checks the package of the config once per run
+func loadRecord(ctx context.Context, run *run, cfg Config) (*loaderRecord, error) {
+	target := cfg.Package == run.target
+	p := run.record(cfg.Package, target)
+	p.Lock()
+	defer p.Unlock()
+	if !p.inited {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		var err error
+		p.path, p.files, p.fileSet, err = collectFiles(cfg.Package, run.blackList, sourceFiles(cfg, target), run.filter)
+		if err != nil {
+			return nil, err
+		}
+		if err = ctx.Err(); err != nil {
+			return nil, err
+		}
+		p.Package, p.packageDefs, err = initPackage(cfg.Package, p.path, p.files, p.fileSet, run.importer)
+		p.inited = true
+	}
+	run.collect(p.path) //shared record is the input too
+	return p, nil
+}
+
+func initPackage(pkgPath, path string, files map[string]*ast.File, fs *token.FileSet, imp types.Importer) (*types.Package, packageDefs, error) {
+
+	/**
+	initializing package parsing with the go/type
+	*/
+
+	defs := make(map[*ast.Ident]types.Object)
+	infos := &types.Info{
+		Defs: defs,
+	}
+
+	var errorsCnt int
+	config := types.Config{
+		FakeImportC:      true,
+		IgnoreFuncBodies: true, //declarations only are generated
+		Error: func(err error) {
+			//log.Println(err)
+		},
+		Importer: imp,
+	}
+
+	if errorsCnt > 0 {
+		info("Warning: During parsing via config.Check number of errors appeared", errorsCnt)
+	}
+
+	var err error
+
+	pkg, err := config.Check(pkgPath, fs, mapToSlice(files), infos)
+
+	if err != nil {
+		log.Println("Warning:", err)
+		//return err
+	}
+
+	return pkg, packageDefs(defs), nil
+}
+
+// todo refactor
+func clearVarDeclaration(string2 string) string {
+	string2 = strings.Replace(strings.Replace(string2, "*", "", 1), "&", "", 1)
+	start, end := strings.Index(string2, "["), strings.Index(string2, "]")
+	if start < 0 || end < 0 {
+		return string2
+	}
+	return string2[0:start]
+}
+
+func isBlackListed(f string, blacklist []string) bool {
+	for _, rec := range blacklist {
+		if strings.HasSuffix(f, rec) {
+			return true
+		}
+	}
+	return false
+}
+
+// outputSuffix suffix of the output file, default suffix is replaced by the suffix of the mode
+func outputSuffix(cfg Config) string {
+	suffix := strings.TrimSpace(cfg.Suffix)
+	if suffix == "" || suffix == "_singleton.go" {
+		return modeSuffix[strings.TrimSpace(cfg.Mode)]
+	}
+	return suffix
+}
+
+func mapToSlice[Key comparable, Value any](from map[Key]Value) []Value {
+	to := make([]Value, 0, len(from))
+	for i := range from {
+		to = append(to, from[i])
+	}
+	return to
+}
+
+func pendingStatementFrom(ctx context.Context) []Delayed {
+	if _statement, ok := ctx.Value("_delayed").([]Delayed); ok {
+		return _statement
+	}
+	return nil
+}
+
+func resolverFrom(ctx context.Context) Resolver {
+	if _statement, ok := ctx.Value("_resolver").(Resolver); ok {
+		return _statement
+	}
+	return nil
+}
+
+func withPending(ctx context.Context, delayed []Delayed) context.Context {
+	return context.WithValue(ctx, "_delayed", delayed)
+}
+
+func withResolver(ctx context.Context, resolver Resolver) context.Context {
+	return context.WithValue(ctx, "_resolver", resolver)
+}
+
+func info(text ...any) {
+	log.Println(text...)
+}
+
+func caution(text any) {
+	log.Println(color(Yellow, fmt.Sprintf("%s", text)))
+}
+
+func critical(text any) {
+	log.Println(color(Red, fmt.Sprintf("%s", text)))
+}
+
+// Critical reports the error which stops the generation
+func Critical(err error) {
+	critical(err)
+}
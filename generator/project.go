@@ -0,0 +1,168 @@
+package generator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ProjectFile name of the file which declares targets of the project
+const ProjectFile = "gosingl.json"
+
+// DefaultComment header comment of the generated file
+const DefaultComment = "Code generated by <git repo>. DO NOT EDIT."
+
+var ProjectError = errors.New("malformed project file")
+
+type project struct {
+	Targets []projectTarget `json:"targets"`
+}
+
+// projectTarget one generated file of the project, fields mirror the flags
+type projectTarget struct {
+	Package      string  `json:"pkg"`
+	Target       string  `json:"target"`
+	Variable     string  `json:"variable,omitempty"`
+	Comment      *string `json:"comment,omitempty"` //default comment if omitted
+	Suffix       string  `json:"suffix,omitempty"`
+	Path         string  `json:"filepath,omitempty"` //relative to the project file
+	Deep         int     `json:"deep,omitempty"`
+	API          bool    `json:"api,omitempty"`
+	Mode         string  `json:"mode,omitempty"`
+	Include      string  `json:"include,omitempty"`
+	Exclude      string  `json:"exclude,omitempty"`
+	OutPackage   string  `json:"outPkg,omitempty"`
+	Strict       bool    `json:"strict,omitempty"`
+	Verify       bool    `json:"verify,omitempty"`
+	IncludeFiles string  `json:"includeFiles,omitempty"`
+	ExcludeFiles string  `json:"excludeFiles,omitempty"`
+	Descend      string  `json:"descend,omitempty"`
+	StopAt       string  `json:"stopAt,omitempty"`
+	Leaf         string  `json:"leaf,omitempty"`
+	IncludeFrom  string  `json:"includeFrom,omitempty"`
+	ExcludeFrom  string  `json:"excludeFrom,omitempty"`
+	Receiver     string  `json:"receiver,omitempty"`
+	Via          string  `json:"via,omitempty"`
+	NamePrefix   string  `json:"namePrefix,omitempty"`
+	NameSuffix   string  `json:"nameSuffix,omitempty"`
+	Rename       string  `json:"rename,omitempty"`
+	NameTemplate string  `json:"nameTemplate,omitempty"`
+}
+
+func (t projectTarget) config(dir string) Config {
+	cfg := Config{
+		Deep:         t.Deep,
+		Package:      t.Package,
+		Target:       t.Target,
+		Variable:     t.Variable,
+		Comment:      DefaultComment,
+		Write:        true,
+		Suffix:       t.Suffix,
+		Path:         t.Path,
+		API:          t.API,
+		Mode:         t.Mode,
+		Include:      t.Include,
+		Exclude:      t.Exclude,
+		OutPackage:   t.OutPackage,
+		Strict:       t.Strict,
+		Verify:       t.Verify,
+		IncludeFiles: t.IncludeFiles,
+		ExcludeFiles: t.ExcludeFiles,
+		Descend:      t.Descend,
+		StopAt:       t.StopAt,
+		Leaf:         t.Leaf,
+		IncludeFrom:  t.IncludeFrom,
+		ExcludeFrom:  t.ExcludeFrom,
+		Receiver:     t.Receiver,
+		Via:          t.Via,
+		NamePrefix:   t.NamePrefix,
+		NameSuffix:   t.NameSuffix,
+		Rename:       t.Rename,
+		NameTemplate: t.NameTemplate,
+	}
+	if t.Comment != nil {
+		cfg.Comment = *t.Comment
+	}
+	if cfg.Path != "" && !filepath.IsAbs(cfg.Path) {
+		cfg.Path = filepath.Join(dir, cfg.Path)
+	}
+	return cfg
+}
+
+// FindProject looks for the project file from the directory up to the module root
+func FindProject(dir string) (string, error) {
+	for {
+		path := filepath.Join(dir, ProjectFile)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			break //module root
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+	return "", fmt.Errorf("%s %w", ProjectFile, NotFoundError)
+}
+
+// GenerateProject generates every target of the project file in one process, see generateTargets
+func (g *Generator) GenerateProject(ctx context.Context, path string, options Config) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.DisallowUnknownFields()
+	declared := project{}
+	if err = decoder.Decode(&declared); err != nil {
+		return fmt.Errorf("%s: %w: %s", path, ProjectError, err)
+	}
+
+	configs, origins := make([]Config, 0, len(declared.Targets)), make([]string, 0, len(declared.Targets))
+	for i, target := range declared.Targets {
+		configs = append(configs, target.config(filepath.Dir(path)))
+		origins = append(origins, fmt.Sprintf("%s: target %d", path, i))
+	}
+	return g.generateTargets(ctx, configs, origins, options)
+}
+
+// generateTargets generates every config in one process, configs with the same file selection share parsed packages.
+// options are the flags which apply to every target (check, diff, force, verify, strict, jobs, explain), origin prefixes the error
+func (g *Generator) generateTargets(ctx context.Context, configs []Config, origins []string, options Config) error {
+	var (
+		target = *g //options of the generator, records of the selection
+		shared = map[string]loaderRecords{}
+		errs   []error
+	)
+	for i, cfg := range configs {
+		cfg.Check, cfg.Diff, cfg.Force, cfg.Jobs, cfg.Explain = options.Check, options.Diff, options.Force, options.Jobs, options.Explain
+		cfg.Verify, cfg.Strict = cfg.Verify || options.Verify, cfg.Strict || options.Strict
+
+		key := selectionKey(g, cfg)
+		if shared[key] == nil {
+			shared[key] = loaderRecords{}
+		}
+		target.records = shared[key]
+		if _, err := target.Generate(ctx, cfg); err != nil {
+			errs = append(errs, fmt.Errorf("%s %s.%s: %w", origins[i], cfg.Package, cfg.Target, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// selectionKey effective selection of the files of the config, as the generation makes it
+func selectionKey(g *Generator, cfg Config) string {
+	blackList := g.blackList
+	if blackList == nil {
+		blackList = defaultBlackList
+	}
+	return fmt.Sprint(outputSuffix(cfg), "|", blackList, "|", strings.TrimSpace(cfg.IncludeFiles), "|", strings.TrimSpace(cfg.ExcludeFiles))
+}
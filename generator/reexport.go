@@ -0,0 +1,191 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"github.com/dave/jennifer/jen"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var ReexportError = errors.New("unable to re-export")
+
+// nameFilter selects names via include and exclude regexp of the config
+type nameFilter struct {
+	include, exclude *regexp.Regexp
+}
+
+func newNameFilter(cfg Config) (*nameFilter, error) {
+	var (
+		filter = &nameFilter{}
+		err    error
+	)
+	if len(strings.TrimSpace(cfg.Include)) > 0 {
+		if filter.include, err = regexp.Compile(cfg.Include); err != nil {
+			return nil, fmt.Errorf("include: %w", err)
+		}
+	}
+	if len(strings.TrimSpace(cfg.Exclude)) > 0 {
+		if filter.exclude, err = regexp.Compile(cfg.Exclude); err != nil {
+			return nil, fmt.Errorf("exclude: %w", err)
+		}
+	}
+	return filter, nil
+}
+
+func (f *nameFilter) Match(name string) bool {
+	if f.include != nil && !f.include.MatchString(name) {
+		return false
+	}
+	if f.exclude != nil && f.exclude.MatchString(name) {
+		return false
+	}
+	return true
+}
+
+// reexportPackage mirrors exported identifiers of the package (cfg.Package) into the target package (cfg.Target):
+// types became aliases, functions became wrappers, constants and variables are re-declared
+func reexportPackage(run *run, cfg Config) error {
+	filter, err := newNameFilter(cfg)
+	if err != nil {
+		return err
+	}
+
+	info("re-export package", cfg.Package, "into", cfg.Target)
+
+	path, files, fileSet, err := collectFiles(cfg.Package, []string{"_test.go"}, sourceFiles(cfg, true), run.filter)
+	if err != nil {
+		return err
+	}
+	run.collect(path)
+	pkg, defs, err := initPackage(cfg.Package, path, files, fileSet, run.importer)
+	if err != nil {
+		return err
+	}
+
+	buffer := jen.NewFilePathName(cfg.Target, packageName(cfg.Target))
+	buffer.HeaderComment(headerLine(cfg))
+	if len(cfg.Comment) > 0 {
+		buffer.PackageComment(cfg.Comment)
+		buffer.Line()
+	}
+
+	resolver := newResolver()
+	indexes := make([]string, 0, len(files))
+	for file := range files {
+		indexes = append(indexes, file)
+	}
+	sort.Strings(indexes)
+
+	for _, file := range indexes {
+		g := newGenerator(run, &pendingParserReq{Config: cfg}, files[file].Imports, nil, defs, path)
+		g.pkg = pkg
+		g.resolver = resolver
+		for _, decl := range files[file].Decls {
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				if decl.Recv != nil || !decl.Name.IsExported() || !filter.Match(decl.Name.Name) {
+					continue
+				}
+				if err := reexportable(cfg, decl.Name, defs, decl.Type.TypeParams); err != nil {
+					caution(err)
+					continue
+				}
+				buffer.Line()
+				reexportFunction(buffer, g, decl, cfg)
+			case *ast.GenDecl:
+				reexportDecl(buffer, decl, defs, filter, cfg)
+			}
+		}
+	}
+
+	//every local type are qualified by source package
+	wait := &sync.WaitGroup{}
+	wait.Add(1)
+	resolver.CompleteResolve(resolveMap{resolve: map[string]string{}}, mapResolveMap{}, wait)
+
+	return writeOutput(run, cfg, buffer, nil)
+}
+
+func reexportFunction(output *jen.File, g *generator, decl *ast.FuncDecl, cfg Config) {
+	params := namedParams(decl.Type.Params, g.namer(), "")
+	call := jen.Qual(cfg.Package, decl.Name.Name).CallFunc(func(group *jen.Group) {
+		for _, param := range params {
+			if param.IsVariadic() {
+				group.Id(param.Name).Op("...")
+			} else {
+				group.Id(param.Name)
+			}
+		}
+	})
+	output.Add(g.buildFunction(decl.Name.Name, decl.Type.Params, decl.Type.Results, g.namer(), false)).BlockFunc(func(group *jen.Group) {
+		if decl.Type.Results.NumFields() > 0 {
+			group.Return(call)
+		} else {
+			group.Add(call)
+		}
+	})
+}
+
+func reexportDecl(output *jen.File, decl *ast.GenDecl, defs packageDefs, filter *nameFilter, cfg Config) {
+	var defined []jen.Code
+	for _, spec := range decl.Specs {
+		switch spec := spec.(type) {
+		case *ast.TypeSpec:
+			if !spec.Name.IsExported() || !filter.Match(spec.Name.Name) {
+				continue
+			}
+			if err := reexportable(cfg, spec.Name, defs, spec.TypeParams); err != nil {
+				caution(err)
+				continue
+			}
+			defined = append(defined, jen.Id(spec.Name.Name).Op("=").Qual(cfg.Package, spec.Name.Name))
+		case *ast.ValueSpec:
+			for _, name := range spec.Names {
+				if !name.IsExported() || !filter.Match(name.Name) {
+					continue
+				}
+				if err := reexportable(cfg, name, defs, nil); err != nil {
+					caution(err)
+					continue
+				}
+				defined = append(defined, jen.Id(name.Name).Op("=").Qual(cfg.Package, name.Name))
+			}
+		}
+	}
+	if len(defined) == 0 {
+		return
+	}
+	output.Line()
+	switch decl.Tok {
+	case token.TYPE:
+		output.Type().Defs(defined...)
+	case token.CONST:
+		output.Const().Defs(defined...)
+	case token.VAR:
+		output.Var().Defs(defined...)
+	}
+}
+
+// reexportable checks that identifier may be referenced from the target package
+func reexportable(cfg Config, ident *ast.Ident, defs packageDefs, typeParams *ast.FieldList) error {
+	if typeParams != nil && typeParams.NumFields() > 0 {
+		return fmt.Errorf("%s %w: generic declaration", ident.Name, ReexportError)
+	}
+	object := defs[ident]
+	if object == nil {
+		return nil //declaration is not type-checked, it is re-exported as is
+	}
+	if _, ok := object.(*types.TypeName); ok {
+		return nil //alias refers to the type itself
+	}
+	if name := inaccessibleType(object.Type(), cfg.Target, map[types.Type]bool{}); name != "" {
+		return fmt.Errorf("%s %w: %s is not accessible from %s", ident.Name, ReexportError, name, cfg.Target)
+	}
+	return nil
+}
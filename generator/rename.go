@@ -0,0 +1,88 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"go/token"
+	"strings"
+	"text/template"
+)
+
+// renameData fields of the name template
+type renameData struct {
+	Name    string   //name of the method
+	Origin  string   //path of the embedded fields from the target to the method, capitalized and joined
+	Path    []string //path of the embedded fields as is
+	Type    string   //type which declares the method
+	Package string   //name of the package of the type
+}
+
+// renamer names of the generated functions, call of the method keeps the original name.
+// mapping wins over the template, prefix and suffix are added to the result of the template
+type renamer struct {
+	mapping        map[string]string //by the method name or the tail of the path of the embedded fields and the name (tl2.SectionReader.Read)
+	template       *template.Template
+	prefix, suffix string
+}
+
+func newRenamer(cfg Config) (*renamer, error) {
+	r := &renamer{
+		mapping: map[string]string{},
+		prefix:  strings.TrimSpace(cfg.NamePrefix),
+		suffix:  strings.TrimSpace(cfg.NameSuffix),
+	}
+	for _, rule := range splitPatterns(cfg.Rename) {
+		old, name, ok := strings.Cut(rule, "=")
+		old, name = strings.TrimSpace(old), strings.TrimSpace(name)
+		if !ok || old == "" || !token.IsIdentifier(name) {
+			return nil, fmt.Errorf("rename: malformed rule %q, Old=New is expected", rule)
+		}
+		r.mapping[old] = name
+	}
+	if strings.TrimSpace(cfg.NameTemplate) != "" {
+		var err error
+		if r.template, err = template.New("name").Option("missingkey=error").Parse(cfg.NameTemplate); err != nil {
+			return nil, fmt.Errorf("name template: %w", err)
+		}
+	}
+	//template is checked once, every name of the generation is checked by the checker
+	sample := renameData{Name: "Read", Origin: "Reader", Path: []string{"Reader"}, Type: "Reader", Package: "io"}
+	if name, err := r.rename(sample); err != nil {
+		return nil, err
+	} else if !token.IsIdentifier(name) {
+		return nil, fmt.Errorf("name template: %q is not an identifier", name)
+	}
+	return r, nil
+}
+
+// Rename name of the generated function of the method
+func (r *renamer) Rename(name string, path []string, cfg Config) string {
+	data := renameData{Name: name, Path: path, Type: cfg.Target, Package: packageName(cfg.Package)}
+	for _, field := range path {
+		data.Origin += strings.ToUpper(field[:1]) + field[1:]
+	}
+	renamed, err := r.rename(data)
+	if err != nil || !token.IsIdentifier(renamed) {
+		info(fmt.Sprintf("WARNING: %s is not renamed: %v %q", name, err, renamed))
+		return name
+	}
+	return renamed
+}
+
+func (r *renamer) rename(data renameData) (string, error) {
+	//longest path of the embedded fields wins, tl2.SectionReader.Read matches tl1.tl2.SectionReader.Read
+	for i := 0; i <= len(data.Path); i++ {
+		if name, ok := r.mapping[strings.Join(append(append([]string{}, data.Path[i:]...), data.Name), ".")]; ok {
+			return name, nil
+		}
+	}
+	name := data.Name
+	if r.template != nil {
+		buffer := &bytes.Buffer{}
+		if err := r.template.Execute(buffer, data); err != nil {
+			return "", fmt.Errorf("name template: %w", err)
+		}
+		name = strings.TrimSpace(buffer.String())
+	}
+	return r.prefix + name + r.suffix, nil
+}
@@ -1,4 +1,4 @@
-package main
+package generator
 
 import (
 	"fmt"
@@ -6,6 +6,7 @@ import (
 	"go/ast"
 	"go/types"
 	"log"
+	"strings"
 	"sync"
 )
 
@@ -38,10 +39,13 @@ type genericResolver struct {
 	pending           []*pendingResolve
 	underlineResolver []Resolver
 	parent            Resolver
+	sync.Mutex        //embedded members are resolved by concurrent routines
 }
 
 func (r *genericResolver) Resolve(ident *ast.Ident, pkg string, object types.Object) *jen.Statement {
 	statement := jen.Id(ident.Name)
+	r.Lock()
+	defer r.Unlock()
 	r.pending = append(r.pending, &pendingResolve{
 		ident: ident,
 		pkg:   pkg,
@@ -60,6 +64,8 @@ func (r *genericResolver) updateItems(resolveMap resolveMap, group *sync.WaitGro
 		} else if resolved, ok := resolveMap.resolve[statement.ident.Name]; ok {
 			if statement.ident.Obj == nil && ISScalarType(resolved) {
 				statement.p.Id(resolved)
+			} else if strings.Contains(resolved, ".") { //resolved by user input, qualified by own package
+				resolvedType(resolved, statement.p)
 			} else {
 				statement.p.Qual(statement.pkg, resolved)
 			}
@@ -122,6 +128,8 @@ func (r *genericResolver) Overlap(pkg, target string, resolve []string) {
 
 func (r *genericResolver) NewResolver() Resolver {
 	resolver := newResolver()
+	r.Lock()
+	defer r.Unlock()
 	r.underlineResolver = append(r.underlineResolver, resolver)
 	resolver.parent = r
 	return resolver
@@ -0,0 +1,310 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"github.com/dave/jennifer/jen"
+	"go/ast"
+	"go/types"
+	"strings"
+)
+
+var RPCSignatureError = errors.New("signature is not supported by net/rpc")
+
+// DefaultClientVariable variable of the rpc-client mode unless other than the default is submitted,
+// so the client and the facade may be generated into the same package
+const DefaultClientVariable = "Client"
+
+// glueRPC declares net/rpc service around the instance of the target and client with the method set of the facade.
+// every method has own <Target><Name>Args and <Target><Name>Reply structures, error results are transferred as text
+func glueRPC(output *jen.File, content []*wrappedFunctionDeclaration, varDecl *variableDecl, cfg Config) {
+	name := rpcName(cfg)
+	service, client := name+"Service", name+"Client"
+
+	output.Comment(fmt.Sprintf("%s exposes instance of the %s via net/rpc", service, strings.TrimSpace(cfg.Comment)))
+	output.Type().Id(service).Struct(jen.Id("instance").Add(varDecl.VarType()))
+	output.Line()
+	output.Comment(fmt.Sprintf("Register%s publishes instance in the server as %s service", service, name))
+	output.Func().Id("Register"+service).Params(jen.Id("server").Op("*").Qual("net/rpc", "Server"), jen.Id("instance").Add(varDecl.VarType())).Error().Block(
+		jen.Return(jen.Id("server").Dot("RegisterName").Call(jen.Lit(name), jen.Op("&").Id(service).Values(jen.Id("instance")))),
+	)
+	output.Line()
+	output.Comment(fmt.Sprintf("%s calls %s service with the signatures of the facade", client, name))
+	output.Type().Id(client).Struct(jen.Id("client").Op("*").Qual("net/rpc", "Client"))
+	output.Line()
+	output.Comment(fmt.Sprintf("New%s creates client which calls remote instance via connected rpc client", client))
+	output.Func().Id("New"+client).Params(jen.Id("client").Op("*").Qual("net/rpc", "Client")).Op("*").Id(client).Block(
+		jen.Return(jen.Op("&").Id(client).Values(jen.Id("client"))),
+	)
+
+	for _, fn := range content {
+		if fn.origin == nil {
+			continue
+		}
+		output.Line()
+		glueRPCMethod(output, fn, name, service, client, rpcErrorName(cfg))
+	}
+
+	output.Line()
+	output.Func().Id(rpcErrorName(cfg)+"Text").Params(jen.Err().Error()).String().Block(
+		jen.If(jen.Err().Op("==").Nil()).Block(jen.Return(jen.Lit(""))),
+		jen.Return(jen.Err().Dot("Error").Call()),
+	)
+	output.Line()
+	output.Func().Id(rpcErrorName(cfg)).Params(jen.Id("text").String()).Error().Block(
+		jen.If(jen.Id("text").Op("==").Lit("")).Block(jen.Return(jen.Nil())),
+		jen.Return(jen.Qual("errors", "New").Call(jen.Id("text"))),
+	)
+}
+
+func glueRPCMethod(output *jen.File, fn *wrappedFunctionDeclaration, name, service, client, errorName string) {
+	var (
+		g       = fn.origin
+		namer   = g.namer()
+		params  = namedParams(fn.Params, namer, "")
+		results = namedParams(fn.Results, namer, "r")
+		args    = name + fn.Name + "Args"
+		reply   = name + fn.Name + "Reply"
+		isError = make([]bool, len(results))
+	)
+	for i, result := range results {
+		isError[i] = isErrorExpr(result)
+	}
+
+	output.Type().Id(args).StructFunc(func(group *jen.Group) {
+		for i, param := range params {
+			group.Id(fmt.Sprintf("P%d", i)).Add(g.buildValueType(param))
+		}
+	})
+	output.Line()
+	output.Type().Id(reply).StructFunc(func(group *jen.Group) {
+		for i, result := range results {
+			if isError[i] {
+				group.Id(fmt.Sprintf("R%d", i)).String()
+			} else {
+				group.Id(fmt.Sprintf("R%d", i)).Add(g.buildValueType(result))
+			}
+		}
+	})
+	output.Line()
+
+	//service side
+	call := jen.Id("service").Dot("instance")
+	for _, prefix := range fn.CallPrefix {
+		call.Dot(prefix)
+	}
+	call.Dot(fn.Method).CallFunc(func(group *jen.Group) {
+		for i, param := range params {
+			if param.IsVariadic() {
+				group.Id("args").Dot(fmt.Sprintf("P%d", i)).Op("...")
+			} else {
+				group.Id("args").Dot(fmt.Sprintf("P%d", i))
+			}
+		}
+	})
+	output.Func().Params(jen.Id("service").Op("*").Id(service)).Id(fn.Name).Params(
+		jen.Id("args").Op("*").Id(args), jen.Id("reply").Op("*").Id(reply),
+	).Error().BlockFunc(func(group *jen.Group) {
+		if len(results) == 0 {
+			group.Add(call)
+			group.Return(jen.Nil())
+			return
+		}
+		values := make([]jen.Code, 0, len(results))
+		for i := range results {
+			values = append(values, jen.Id(fmt.Sprintf("r%d", i)))
+		}
+		group.List(values...).Op(":=").Add(call)
+		for i := range results {
+			if isError[i] {
+				group.Id("reply").Dot(fmt.Sprintf("R%d", i)).Op("=").Id(errorName + "Text").Call(jen.Id(fmt.Sprintf("r%d", i)))
+			} else {
+				group.Id("reply").Dot(fmt.Sprintf("R%d", i)).Op("=").Id(fmt.Sprintf("r%d", i))
+			}
+		}
+		group.Return(jen.Nil())
+	})
+	output.Line()
+
+	//client side
+	var (
+		receiver = freeName(namer, "client", params)
+		rpcArgs  = freeName(namer, "rpcArgs", params)
+		rpcReply = freeName(namer, "rpcReply", params)
+		rpcErr   = freeName(namer, "rpcErr", params)
+	)
+	output.Func().Params(jen.Id(receiver).Op("*").Id(client)).Id(fn.Name).ParamsFunc(func(group *jen.Group) {
+		for _, param := range params {
+			group.Id(param.Name).Add(g.recursBuildParam(param.Field.Type, &jen.Statement{}))
+		}
+	}).ParamsFunc(func(group *jen.Group) {
+		for _, result := range results {
+			group.Add(g.recursBuildParam(result.Field.Type, &jen.Statement{}))
+		}
+	}).BlockFunc(func(group *jen.Group) {
+		group.Id(rpcArgs).Op(":=").Op("&").Id(args).ValuesFunc(func(group *jen.Group) {
+			for i, param := range params {
+				group.Id(fmt.Sprintf("P%d", i)).Op(":").Id(param.Name)
+			}
+		})
+		group.Id(rpcReply).Op(":=").Op("&").Id(reply).Values()
+		transport := jen.Id(rpcErr).Op(":=").Id(receiver).Dot("client").Dot("Call").Call(
+			jen.Lit(name+"."+fn.Name), jen.Id(rpcArgs), jen.Id(rpcReply),
+		)
+
+		errorReturn, valueReturn := make([]jen.Code, 0, len(results)), make([]jen.Code, 0, len(results))
+		for i := range results {
+			if isError[i] {
+				errorReturn = append(errorReturn, jen.Id(rpcErr))
+				valueReturn = append(valueReturn, jen.Id(errorName).Call(jen.Id(rpcReply).Dot(fmt.Sprintf("R%d", i))))
+			} else {
+				errorReturn = append(errorReturn, jen.Id(rpcReply).Dot(fmt.Sprintf("R%d", i)))
+				valueReturn = append(valueReturn, jen.Id(rpcReply).Dot(fmt.Sprintf("R%d", i)))
+			}
+		}
+		group.If(transport, jen.Id(rpcErr).Op("!=").Nil()).Block(jen.Return(errorReturn...))
+		if len(results) > 0 {
+			group.Return(valueReturn...)
+		}
+	})
+}
+
+// glueRPCClient declares package level functions with the signatures of the facade which calls remote instance via
+// <Target>Client, it meant to be used instead of the facade
+func glueRPCClient(output *jen.File, content []*wrappedFunctionDeclaration, cfg Config, variable string) {
+	client := rpcName(cfg) + "Client"
+
+	output.Var().Id(variable).Op("*").Id(client)
+	for _, fn := range content {
+		if fn.origin == nil {
+			continue
+		}
+		g := fn.origin
+		namer := g.namer()
+		params := namedParams(fn.Params, namer, "")
+		call := jen.Id(variable).Dot(fn.Name).CallFunc(func(group *jen.Group) {
+			for _, param := range params {
+				if param.IsVariadic() {
+					group.Id(param.Name).Op("...")
+				} else {
+					group.Id(param.Name)
+				}
+			}
+		})
+		output.Line()
+		output.Add(g.buildFunction(fn.Name, fn.Params, fn.Results, g.namer(), false)).BlockFunc(func(group *jen.Group) {
+			if fn.Results.NumFields() > 0 {
+				group.Return(call)
+			} else {
+				group.Add(call)
+			}
+		})
+	}
+}
+
+// rpcContent keeps functions which may be called via net/rpc, others are skipped with the diagnostic or fail in the strict mode
+func rpcContent(run *run, cfg Config, content []*wrappedFunctionDeclaration) ([]*wrappedFunctionDeclaration, error) {
+	result := make([]*wrappedFunctionDeclaration, 0, len(content))
+	for _, fn := range content {
+		if fn.origin == nil {
+			continue
+		}
+		if err := rpcSupported(fn); err != nil {
+			err = fmt.Errorf("%s: %w", position(run, fn), err)
+			if cfg.Strict {
+				return nil, err
+			}
+			run.diagnose(err.Error())
+			continue
+		}
+		result = append(result, fn)
+	}
+	return result, nil
+}
+
+// rpcSupported checks that every parameter and result may be transferred by encoding/gob
+// and the signature has the error result to return the transport error
+func rpcSupported(fn *wrappedFunctionDeclaration) error {
+	hasError := false
+	for _, result := range namedParams(fn.Results, fn.origin.namer(), "r") {
+		hasError = hasError || isErrorExpr(result)
+	}
+	if !hasError {
+		return fmt.Errorf("%s skipped: %w, no error result to return the transport error", fn.Name, RPCSignatureError)
+	}
+	signature := fn.signature()
+	if signature == nil {
+		return nil //unresolved types are transferred as declared, gob reports the unsupported one on the call
+	}
+	for i := 0; i < signature.Params().Len(); i++ {
+		if reason := rpcUnsupported(signature.Params().At(i).Type(), map[types.Type]bool{}); reason != "" {
+			return fmt.Errorf("%s skipped: %w, parameter %d is %s", fn.Name, RPCSignatureError, i, reason)
+		}
+	}
+	for i := 0; i < signature.Results().Len(); i++ {
+		typeOf := signature.Results().At(i).Type()
+		if types.Identical(typeOf, types.Universe.Lookup("error").Type()) {
+			continue //transferred as text
+		}
+		if reason := rpcUnsupported(typeOf, map[types.Type]bool{}); reason != "" {
+			return fmt.Errorf("%s skipped: %w, result %d is %s", fn.Name, RPCSignatureError, i, reason)
+		}
+	}
+	return nil
+}
+
+func rpcUnsupported(typeOf types.Type, seen map[types.Type]bool) string {
+	if seen[typeOf] {
+		return ""
+	}
+	seen[typeOf] = true
+	switch t := typeOf.(type) {
+	case *types.Named:
+		return rpcUnsupported(t.Underlying(), seen)
+	case *types.Basic:
+		if t.Kind() == types.UnsafePointer {
+			return "unsafe pointer"
+		}
+	case *types.Chan:
+		return "channel"
+	case *types.Signature:
+		return "function"
+	case *types.Interface:
+		if !t.Empty() {
+			return fmt.Sprintf("interface %s", typeOf)
+		}
+	case *types.Pointer:
+		return rpcUnsupported(t.Elem(), seen)
+	case *types.Slice:
+		return rpcUnsupported(t.Elem(), seen)
+	case *types.Array:
+		return rpcUnsupported(t.Elem(), seen)
+	case *types.Map:
+		if reason := rpcUnsupported(t.Key(), seen); reason != "" {
+			return reason
+		}
+		return rpcUnsupported(t.Elem(), seen)
+	case *types.Struct:
+		for i := 0; i < t.NumFields(); i++ {
+			if t.Field(i).Exported() { //gob ignores unexported fields
+				if reason := rpcUnsupported(t.Field(i).Type(), seen); reason != "" {
+					return reason
+				}
+			}
+		}
+	}
+	return ""
+}
+
+func isErrorExpr(param namedParam) bool {
+	ident, ok := param.Field.Type.(*ast.Ident)
+	return ok && ident.Name == "error"
+}
+
+func rpcName(cfg Config) string {
+	return strings.ToUpper(cfg.Target[0:1]) + cfg.Target[1:]
+}
+
+func rpcErrorName(cfg Config) string {
+	return strings.ToLower(cfg.Target[0:1]) + cfg.Target[1:] + "RPCError"
+}
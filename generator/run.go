@@ -0,0 +1,181 @@
+package generator
+
+import (
+	"fmt"
+	"go/types"
+	"io"
+	"sort"
+	"sync"
+)
+
+// run state of the one generation, generations of the same process do not share it.
+// options of the Generator are kept here and passed explicitly to the routines
+type run struct {
+	writer    io.Writer //generated code is written into, file or stdout if nil
+	namer     Namer
+	checker   Checker
+	blackList []string
+	filter    fileFilter //in addition to the config globs
+	renamer   *renamer
+	target    string         //package of the target, outputs of the gosingl are skipped in it only
+	importer  types.Importer //shared by the packages of the run
+	result    *Result
+
+	records    loaderRecords
+	recordsMux sync.Mutex
+
+	pending    []*pendingParserReq
+	pendingMux sync.Mutex
+
+	children  map[string]int  //number of the requests scheduled by the task, see child
+	processed map[string]bool //package and target which are generated already
+	collected map[string]bool //directories which are read during the generation, every file of them is an input
+	mux       sync.Mutex
+}
+
+// newRun options of the generator, unset are defaulted. parsed packages may be shared, but every target is processed anew
+func newRun(g *Generator, result *Result) *run {
+	r := &run{
+		writer:    g.writer,
+		namer:     g.namer,
+		checker:   g.checker,
+		blackList: g.blackList,
+		filter:    g.filter,
+		renamer:   &renamer{}, //names are kept
+		importer:  newExportImporter(exportDir()),
+		result:    result,
+		records:   g.records,
+		children:  map[string]int{},
+		processed: map[string]bool{},
+		collected: map[string]bool{},
+	}
+	if r.namer == nil {
+		r.namer = newParameterNamer()
+	}
+	if r.checker == nil {
+		r.checker = newUniqueChecker(nil)
+	}
+	if r.blackList == nil {
+		r.blackList = defaultBlackList
+	}
+	if r.result == nil {
+		r.result = &Result{} //nobody is interested
+	}
+	if r.records == nil {
+		r.records = make(loaderRecords)
+	}
+	return r
+}
+
+// record of the package, new record is created if package is not loaded yet.
+// shared records differ by the selection of the files, package of the target has own one
+func (r *run) record(pkg string, target bool) *loaderRecord {
+	r.recordsMux.Lock()
+	defer r.recordsMux.Unlock()
+	key := recordKey(pkg, target)
+	p, ok := r.records[key]
+	if !ok {
+		p = &loaderRecord{}
+		r.records[key] = p
+	}
+	return p
+}
+
+// loaded record of the package, false if package is not loaded
+func (r *run) loaded(pkg string) (*loaderRecord, bool) {
+	r.recordsMux.Lock()
+	defer r.recordsMux.Unlock()
+	p, ok := r.records[recordKey(pkg, pkg == r.target)]
+	return p, ok
+}
+
+func recordKey(pkg string, target bool) string {
+	if target {
+		return pkg + " (target)"
+	}
+	return pkg
+}
+
+func (r *run) schedule(req *pendingParserReq) {
+	r.pendingMux.Lock()
+	r.pending = append(r.pending, req)
+	r.pendingMux.Unlock()
+}
+
+// takePending returns requests scheduled since the previous call
+func (r *run) takePending() []*pendingParserReq {
+	r.pendingMux.Lock()
+	defer r.pendingMux.Unlock()
+	tasks := r.pending
+	r.pending = nil
+	return tasks
+}
+
+// child position of the next request scheduled by the task of the parent position,
+// requests of the task are scheduled one by one, so position does not depend on timing of the other tasks
+func (r *run) child(parent []int) []int {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	key := fmt.Sprint(parent)
+	order := append(append(make([]int, 0, len(parent)+1), parent...), r.children[key])
+	r.children[key]++
+	return order
+}
+
+// orderLess tasks of the upper level go first
+func orderLess(a, b []int) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return false
+}
+
+// process marks target of the package as generated, false if it is generated already
+func (r *run) process(pkg, target string) bool {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	key := pkg + "." + target
+	if r.processed[key] {
+		return false
+	}
+	r.processed[key] = true
+	return true
+}
+
+func (r *run) collect(dir string) {
+	r.mux.Lock()
+	r.collected[dir] = true
+	r.mux.Unlock()
+}
+
+func (r *run) collectedDirs() []string {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	dirs := make([]string, 0, len(r.collected))
+	for dir := range r.collected {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+	return dirs
+}
+
+// diagnose reports the problem which does not stop the generation
+func (r *run) diagnose(text string) {
+	caution(text)
+	r.result.Diagnostics = append(r.result.Diagnostics, text)
+}
+
+// explain reports the traversal rule applied to the member, silent unless Config.Explain is set
+func (r *run) explain(cfg Config, format string, args ...any) {
+	if !cfg.Explain {
+		return
+	}
+	text := fmt.Sprintf(format, args...)
+	info("explain:", text)
+	r.result.Explain = append(r.result.Explain, text)
+}
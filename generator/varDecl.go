@@ -1,4 +1,4 @@
-package main
+package generator
 
 import (
 	"context"
@@ -31,6 +31,40 @@ func (v *variableDecl) Declare() *jen.Statement {
 	return v.instance
 }
 
+// Reference of the variable which may be assigned to interface with the target method set
+func (v *variableDecl) Reference() *jen.Statement {
+	if v.vType == Real && !v.IsInterface() {
+		return jen.Op("&").Id(v.Variable)
+	}
+	return jen.Id(v.Variable)
+}
+
+// TargetType type of the target including resolved generics
+func (v *variableDecl) TargetType() *jen.Statement {
+	statement := jen.Qual(v.Package, v.Target)
+	if len(v.resolved) > 0 {
+		statement.TypesFunc(func(group *jen.Group) {
+			for _, resolve := range v.resolved {
+				group.Add(resolvedType(resolve, &jen.Statement{}))
+			}
+		})
+	}
+	return statement
+}
+
+// VarType type of the variable
+func (v *variableDecl) VarType() *jen.Statement {
+	if v.vType == Real {
+		return v.TargetType()
+	}
+	return jen.Op("*").Add(v.TargetType())
+}
+
+// IsInterface target is interface type
+func (v *variableDecl) IsInterface() bool {
+	return v.target != nil && isInterfaceSpec(v.target)
+}
+
 func (v *variableDecl) Do(ctx context.Context, target *ast.TypeSpec, targetMethods []ast.Node, cfg Config) {
 	v.processMut.Lock()
 	defer v.processMut.Unlock()
@@ -128,43 +162,28 @@ func (v *variableDecl) updateVType(target *ast.TypeSpec, targetMethods []ast.Nod
 
 func (v *variableDecl) update() {
 	resetStatement(v.instance)
-	switch v.vType {
-	case Real:
-		v.instance.Id(v.Variable).Qual(v.Package, v.Target)
-	case Ref:
-		v.instance.Id(v.Variable).Op("*").Qual(v.Package, v.Target)
-	default:
-		v.instance.Id(v.Variable).Op("*").Qual(v.Package, v.Target)
+	v.instance.Id(v.Variable).Add(v.VarType())
+}
+
+// resolvedType build type from user input of generic resolve such as *os.File
+func resolvedType(resolve string, statement *jen.Statement) *jen.Statement {
+	if strings.Index(resolve, "*") == 0 {
+		statement.Op("*")
+		if len(resolve) > 1 {
+			resolve = strings.TrimSpace(resolve[1:]) //drop symbol *
+		}
 	}
-	if len(v.resolved) > 0 {
-		v.instance.TypesFunc(func(group *jen.Group) {
-			for _, resolve := range v.resolved {
-				var (
-					statement *jen.Statement = &jen.Statement{}
-				)
-				if strings.Index(resolve, "*") == 0 {
-					statement.Op("*")
-					if len(resolve) > 1 {
-						resolve = strings.TrimSpace(resolve[1:]) //drop symbol *
-					}
-				}
-				if strings.Contains(resolve, ".") {
-					qual := strings.Split(resolve, ".")
-					if len(qual) == 2 {
-						qual[0], qual[1] = strings.TrimSpace(qual[0]), strings.TrimSpace(qual[1])
-						group.Add(statement.Qual(qual[0], qual[1]))
-					} else {
-						critical(fmt.Sprintf("WARNING: probably incorrect generic resolve %s", resolve))
-						group.Add(statement.Id(resolve))
-					}
-				} else {
-					group.Add(statement.Id(resolve))
-				}
-			}
-		})
-	} else {
-		//log.Println("empty resolver")
+	if strings.Contains(resolve, ".") {
+		qual := strings.Split(resolve, ".")
+		if len(qual) == 2 {
+			qual[0], qual[1] = strings.TrimSpace(qual[0]), strings.TrimSpace(qual[1])
+			return statement.Qual(qual[0], qual[1])
+		} else {
+			critical(fmt.Sprintf("WARNING: probably incorrect generic resolve %s", resolve))
+			return statement.Id(resolve)
+		}
 	}
+	return statement.Id(resolve)
 }
 
 func newVariableDecl(cfg Config, vType vType, resolved []string) *variableDecl {
@@ -194,6 +213,11 @@ func newVariableDeclFromConfig(cfg Config) *variableDecl {
 	}
 }
 
+func isInterfaceSpec(spec *ast.TypeSpec) bool {
+	_, ok := spec.Type.(*ast.InterfaceType)
+	return ok
+}
+
 // todo refactor
 func resolveGenerics2(cfg Config) []string {
 	start, end := strings.Index(cfg.Variable, "["), strings.Index(cfg.Variable, "]")
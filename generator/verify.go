@@ -0,0 +1,75 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strings"
+)
+
+var VerifyError = errors.New("generated code does not compile")
+
+// verifyOutput type-checks rendered file together with the rest of the output package (previously generated file
+// excluded), errors of the rendered file are mapped back to the functions which produced them
+func verifyOutput(run *run, cfg Config, rendered []byte, content []*wrappedFunctionDeclaration) error {
+	generated, err := outputPath(cfg)
+	if err != nil {
+		return err
+	}
+
+	path, files, fileSet, err := collectFiles(outputPackage(cfg), []string{"_test.go", generated})
+	if err != nil {
+		return err
+	}
+	run.collect(path)
+	file, err := parser.ParseFile(fileSet, generated, rendered, 0)
+	if err != nil {
+		return fmt.Errorf("%w: %s", VerifyError, err)
+	}
+	//generated file goes last, so collisions are reported in it
+	checked := append(mapToSlice(files), file)
+
+	var problems []string
+	config := types.Config{
+		FakeImportC: true,
+		Importer:    importer.ForCompiler(fileSet, "source", nil),
+		Error: func(err error) {
+			typeErr, ok := err.(types.Error)
+			if !ok || strings.HasPrefix(typeErr.Msg, "\t") {
+				return //continuation of the previous error
+			}
+			at := typeErr.Fset.Position(typeErr.Pos)
+			if at.Filename != generated {
+				return //problem of the package itself
+			}
+			problems = append(problems, fmt.Sprintf("%s: %s%s", at, typeErr.Msg, origin(run, file, typeErr.Pos, content)))
+		},
+	}
+	_, _ = config.Check(outputPackage(cfg), fileSet, checked, nil)
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%w:\n%s", VerifyError, strings.Join(problems, "\n"))
+	}
+	return nil
+}
+
+// origin describes function which produced the declaration at the position, empty if it is unknown
+func origin(run *run, file *ast.File, pos token.Pos, content []*wrappedFunctionDeclaration) string {
+	for _, decl := range file.Decls {
+		fnDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || pos < fnDecl.Pos() || pos > fnDecl.End() {
+			continue
+		}
+		for _, fn := range content {
+			if fn.Name == fnDecl.Name.Name {
+				return fmt.Sprintf(" (%s of %s.%s declared at %s)", fn.Name, fn.Package, fn.Target, position(run, fn))
+			}
+		}
+		return fmt.Sprintf(" (%s)", fnDecl.Name.Name)
+	}
+	return ""
+}
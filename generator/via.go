@@ -0,0 +1,104 @@
+package generator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go/types"
+	"strings"
+)
+
+var NotImplementedError = errors.New("does not implement")
+
+// splitVia package and name of the interface of the --via, interface of the target package may be unqualified
+func splitVia(cfg Config) (pkg, name string) {
+	i := strings.LastIndex(cfg.Via, ".")
+	if i < 0 || i < strings.LastIndex(cfg.Via, "/") {
+		return cfg.Package, cfg.Via
+	}
+	return cfg.Via[:i], cfg.Via[i+1:]
+}
+
+// checkVia target implements the interface of the --via, every missing or mismatched method is reported
+// with the position of the interface method
+func checkVia(ctx context.Context, run *run, cfg Config) (pkg, name string, err error) {
+	pkg, name = splitVia(cfg)
+	iface, err := loadRecord(ctx, run, Config{Package: pkg, IncludeFiles: cfg.IncludeFiles, ExcludeFiles: cfg.ExcludeFiles})
+	if err != nil {
+		return "", "", err
+	}
+	target, err := loadRecord(ctx, run, cfg)
+	if err != nil {
+		return "", "", err
+	}
+
+	ifaceName, ok := iface.Scope().Lookup(name).(*types.TypeName)
+	if !ok || !types.IsInterface(ifaceName.Type()) {
+		return "", "", fmt.Errorf("via %s: interface %w", cfg.Via, NotFoundError)
+	}
+	targetName, ok := target.Scope().Lookup(cfg.Target).(*types.TypeName)
+	if !ok {
+		return "", "", fmt.Errorf("%s: %s %w", cfg.Package, cfg.Target, NotFoundError)
+	}
+	receiver := targetName.Type()
+	if !types.IsInterface(receiver) {
+		receiver = types.NewPointer(receiver) //methods of the value are in the method set of the pointer
+	}
+
+	qualifier := func(p *types.Package) string {
+		return p.Path()
+	}
+	var errs []error
+	methods := ifaceName.Type().Underlying().(*types.Interface)
+	for i := 0; i < methods.NumMethods(); i++ {
+		required := methods.Method(i)
+		at := recordPosition(iface, required)
+		if at == "" { //promoted from the imported interface
+			at = recordPosition(iface, ifaceName)
+		}
+		found, _, _ := types.LookupFieldOrMethod(receiver, true, target.Package, required.Name())
+		method, ok := found.(*types.Func)
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s%s %w %s: missing method %s", at, cfg.Target, NotImplementedError, cfg.Via, required.Name()))
+			continue
+		}
+		has, wants := signatureString(method.Type().(*types.Signature), qualifier), signatureString(required.Type().(*types.Signature), qualifier)
+		if has != wants {
+			errs = append(errs, fmt.Errorf("%s%s %w %s: method %s has %s, wants %s",
+				at, cfg.Target, NotImplementedError, cfg.Via, required.Name(), has, wants))
+		}
+	}
+	return pkg, name, errors.Join(errs...)
+}
+
+// signatureString signature without the names of the parameters, types are qualified by the import path,
+// so signatures of the different type-checks are comparable
+func signatureString(signature *types.Signature, qualifier types.Qualifier) string {
+	tuple := func(vars *types.Tuple, variadic bool) string {
+		list := make([]string, 0, vars.Len())
+		for i := 0; i < vars.Len(); i++ {
+			if variadic && i == vars.Len()-1 {
+				list = append(list, "..."+types.TypeString(vars.At(i).Type().(*types.Slice).Elem(), qualifier))
+				continue
+			}
+			list = append(list, types.TypeString(vars.At(i).Type(), qualifier))
+		}
+		return strings.Join(list, ", ")
+	}
+	result := "func(" + tuple(signature.Params(), signature.Variadic()) + ")"
+	switch signature.Results().Len() {
+	case 0:
+		return result
+	case 1:
+		return result + " " + tuple(signature.Results(), false)
+	}
+	return result + " (" + tuple(signature.Results(), false) + ")"
+}
+
+// recordPosition position of the object declared in the package of the record, empty for the imported one
+func recordPosition(record *loaderRecord, object types.Object) string {
+	if object.Pkg() == nil || object.Pkg().Path() != record.Path() || record.fileSet.File(object.Pos()) == nil {
+		return ""
+	}
+	return record.fileSet.Position(object.Pos()).String() + ": "
+}
@@ -0,0 +1,143 @@
+package generator
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// watchTarget generated file and directories which were read during its generation
+type watchTarget struct {
+	cfg  Config
+	dirs []string
+}
+
+// Watch regenerates files with the header of the patterns when the inputs change. Directories of every package
+// which was read during the generation (deep dependencies included) are polled with the interval, target is
+// regenerated when its inputs stay unchanged for the debounce period. Summary is printed to the out
+func Watch(ctx context.Context, patterns []string, interval, debounce time.Duration, out io.Writer) error {
+	var targets []*watchTarget
+	for _, pattern := range patterns {
+		files, err := generatedFiles(pattern)
+		if err != nil {
+			return err
+		}
+		for _, path := range files {
+			cfg, _, err := readHeader(path)
+			if err != nil {
+				return err
+			}
+			if cfg.Path, err = filepath.Abs(path); err != nil {
+				return err
+			}
+			cfg.Write, cfg.Force = true, true //inputs are collected even if file is up to date
+			targets = append(targets, &watchTarget{cfg: cfg})
+		}
+	}
+	outputs := map[string]bool{}
+	for _, target := range targets {
+		outputs[target.cfg.Path] = true
+		target.generate(ctx, out)
+	}
+	fmt.Fprintf(out, "watching %d files\n", len(targets))
+
+	state := dirStates(targets, outputs)
+	changed := map[string]bool{}
+	var lastChange time.Time
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case now := <-ticker.C:
+			current := dirStates(targets, outputs)
+			for dir, signature := range current {
+				if state[dir] != signature {
+					changed[dir], lastChange = true, now
+				}
+			}
+			state = current
+			if len(changed) == 0 || now.Sub(lastChange) < debounce {
+				continue //burst of the edits is not finished
+			}
+			for _, target := range targets {
+				if target.affected(changed) {
+					target.generate(ctx, out)
+				}
+			}
+			changed = map[string]bool{}
+			state = dirStates(targets, outputs) //new dependencies of the regenerated targets
+		}
+	}
+}
+
+// generate regenerates the file and remembers its inputs, short summary is printed to the out
+func (t *watchTarget) generate(ctx context.Context, out io.Writer) {
+	start := time.Now()
+	previous, _ := os.ReadFile(t.cfg.Path)
+	result, err := New().Generate(ctx, t.cfg)
+	if len(result.Inputs) > 0 {
+		t.dirs = result.Inputs
+	}
+	elapsed := time.Since(start).Round(time.Millisecond)
+	switch current, _ := os.ReadFile(t.cfg.Path); {
+	case err != nil:
+		fmt.Fprintf(out, "%s: %s (%s)\n", t.cfg.Path, err, elapsed)
+	case bytes.Equal(previous, current):
+		fmt.Fprintf(out, "%s: unchanged (%s)\n", t.cfg.Path, elapsed)
+	default:
+		fmt.Fprintf(out, "%s: updated (%s)\n", t.cfg.Path, elapsed)
+	}
+}
+
+func (t *watchTarget) affected(changed map[string]bool) bool {
+	for _, dir := range t.dirs {
+		if changed[dir] {
+			return true
+		}
+	}
+	return false
+}
+
+// dirStates signature of the go files of every watched directory, generated files are not inputs
+func dirStates(targets []*watchTarget, outputs map[string]bool) map[string]string {
+	states := map[string]string{}
+	for _, target := range targets {
+		for _, dir := range target.dirs {
+			if _, ok := states[dir]; !ok {
+				states[dir] = dirState(dir, outputs)
+			}
+		}
+	}
+	return states
+}
+
+// dirState name, size and modification time of the go files, removed directory has an empty state
+func dirState(dir string, outputs map[string]bool) string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return ""
+	}
+	hash := sha256.New()
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".go") || outputs[path] {
+			continue
+		}
+		stat, err := entry.Info()
+		if err != nil {
+			continue //removed during the walk, next poll notices it
+		}
+		fmt.Fprintf(hash, "%s %d %d\n", entry.Name(), stat.Size(), stat.ModTime().UnixNano())
+	}
+	return string(hash.Sum(nil))
+}
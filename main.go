@@ -2,31 +2,70 @@ package main
 
 import (
 	"context"
+	"github.com/alh1m1k/gosingl/generator"
 	cli "github.com/jawher/mow.cli"
 	"os"
+	"os/signal"
 	"time"
 )
 
 func main() {
 
-	cfg := Config{}
+	cfg := generator.Config{}
 
 	delay := 0
+	projectPath := ""
 
 	app := cli.App("gosingl", "generate module level singleton")
-	app.StringArgPtr(&cfg.Package, "PKG", "", "package to walk to")
+	app.Version("version", generator.Version)
+	app.Spec = "[OPTIONS] [PKG [TARGET]]" //commands do not require arguments of the generation
+	app.StringArgPtr(&cfg.Package, "PKG", "", "package to walk to.\n without TARGET it is a directory pattern (dir/... includes subdirectories),\n types annotated with "+generator.AnnotationPrefix+" are generated")
 	app.StringArgPtr(&cfg.Target, "TARGET", "", "Structure will be use as module singleton")
-	app.StringOptPtr(&cfg.Variable, "variable", "Instance", "singleton instance (module variable).\n *Instance declare var as real,\n "+
+	app.StringOptPtr(&cfg.Variable, "variable", "", "singleton instance (module variable), Instance by default, Client in the rpc-client mode.\n *Instance declare var as real,\n "+
 		"&Instance declare var as ref, Instance[T,K,Z] resolves generic")
-	app.StringOptPtr(&cfg.Comment, "comment", "Code generated by <git repo>. DO NOT EDIT.", "file header comment")
+	app.StringOptPtr(&cfg.Comment, "comment", generator.DefaultComment, "file header comment")
 	app.StringOptPtr(&cfg.Suffix, "suffix", "_singleton.go", "suffix of generated file")
 	app.StringOptPtr(&cfg.Path, "filepath", "", "path override")
 	app.IntOptPtr(&cfg.Deep, "deep", 0, "recursive deep")
+	app.BoolOptPtr(&cfg.API, "api", false, "declare <TARGET>API interface of the facade")
+	app.StringOptPtr(&cfg.Mode, "mode", "", "what to generate: facade (by default), fake (Fake<TARGET> recording structure)\n "+
+		"test (forwarding tests of the facade, interface target only), rpc (net/rpc service and client of the instance)\n "+
+		"rpc-client (facade which calls remote instance) or reexport (mirror API of PKG into TARGET package)")
+	app.StringOptPtr(&cfg.Include, "include", "", "regexp of the names to generate")
+	app.StringOptPtr(&cfg.Exclude, "exclude", "", "regexp of the names to skip")
+	app.StringOptPtr(&cfg.IncludeFrom, "include-from", "", "comma separated origins of the functions to generate:\n "+
+		"path of the embedded fields (tl2.SectionReader) or declaring type (io.SectionReader)")
+	app.StringOptPtr(&cfg.ExcludeFrom, "exclude-from", "", "comma separated origins of the functions to skip")
+	app.StringOptPtr(&cfg.Via, "via", "", "interface (pkg.Interface or Interface of PKG) whose methods are generated,\n "+
+		"TARGET must implement it, signatures are taken from the interface")
+	app.StringOptPtr(&cfg.NamePrefix, "name-prefix", "", "prefix of the names of the generated functions")
+	app.StringOptPtr(&cfg.NameSuffix, "name-suffix", "", "suffix of the names of the generated functions")
+	app.StringOptPtr(&cfg.Rename, "rename", "", "comma separated Old=New names of the generated functions,\n "+
+		"Old is the method name or the path of the embedded fields and the name (tl2.SectionReader.Read)")
+	app.StringOptPtr(&cfg.NameTemplate, "name-template", "", "template of the names of the generated functions, {{.Origin}}{{.Name}} for example.\n "+
+		".Name is the method, .Origin is the capitalized path of the embedded fields, .Type and .Package declare the method")
+	app.StringOptPtr(&cfg.Receiver, "receiver", "", "receiver of the methods to generate: value or pointer, both by default")
+	app.StringOptPtr(&cfg.OutPackage, "out-pkg", "", "package of the generated file, package of the TARGET by default")
+	app.BoolOptPtr(&cfg.Strict, "strict", false, "fail instead of skip function which may not be generated")
+	app.BoolOptPtr(&cfg.Verify, "verify", false, "type-check generated code and refuse to write it on failure")
+	app.BoolOptPtr(&cfg.Check, "check", false, "print unified diff with the generated file and fail if it is stale, nothing is written")
+	app.BoolOptPtr(&cfg.Diff, "diff", false, "print unified diff with the generated file, nothing is written")
+	app.BoolOptPtr(&cfg.Force, "force", false, "generate even if inputs of the file are not changed")
+	app.StringOptPtr(&cfg.IncludeFiles, "include-files", "", "comma separated globs of the file names to parse")
+	app.StringOptPtr(&cfg.ExcludeFiles, "exclude-files", "", "comma separated globs of the file names to skip")
+	app.StringOptPtr(&projectPath, "project", "", "project file, "+generator.ProjectFile+" of the current directory or its parents up to the module root by default.\n "+
+		"used if PKG and TARGET are omitted outside of go generate")
+	app.StringOptPtr(&cfg.Descend, "descend", "", "comma separated patterns of the packages whose embedded members are walked, all by default.\n "+
+		"std is the standard library, module is the module of PKG, path/... includes subpackages")
+	app.StringOptPtr(&cfg.StopAt, "stop-at", "", "comma separated patterns of the packages whose embedded members are not walked")
+	app.StringOptPtr(&cfg.Leaf, "leaf", "", "what embedded member of the stopped package contributes: methods (declared by itself, by default) or none")
+	app.BoolOptPtr(&cfg.Explain, "explain", false, "print the traversal rules applied to the embedded members")
+	app.IntOptPtr(&cfg.Jobs, "j jobs", 0, "number of the packages processed in parallel, number of CPUs by default")
 	app.IntOptPtr(&delay, "delay", 0, "debug only")
 	app.BoolOptPtr(&cfg.Write, "w write", false, "writes the result in file")
 
 	exitOnError := func(err error) {
-		critical(err)
+		generator.Critical(err)
 		os.Exit(1)
 	}
 
@@ -36,19 +75,68 @@ func main() {
 			time.Sleep(time.Second * time.Duration(delay))
 		}
 		ctx := context.Background()
-		ctx = SetupCtx(ctx, //as reference
-			nil, //global output target //"write" and "file" flags will be ignored if set
-			nil, //shared buffer, mostly internal
-			nil, //helper to generate name for unnamed function input parameters
-			nil, //checker which excludes duplicates from output
-			nil, //blacklisted file suffix ([]string{"_test.go", cfg.Suffix},)
-		)
-		err = ParsePackage(ctx, cfg)
+		if cfg.Package == "" && cfg.Target == "" && projectPath == "" && os.Getenv("GOFILE") != "" {
+			//invoked by go generate, target is the type below the directive
+			if cfg.Package, cfg.Target, err = generator.GoGenerateTarget("."); err != nil {
+				exitOnError(err)
+			}
+		}
+		if cfg.Package == "" && cfg.Target == "" {
+			//targets of the project file
+			if projectPath == "" {
+				dir, err := os.Getwd()
+				if err != nil {
+					exitOnError(err)
+				}
+				if projectPath, err = generator.FindProject(dir); err != nil {
+					exitOnError(err)
+				}
+			}
+			err = generator.New().GenerateProject(ctx, projectPath, cfg)
+		} else if cfg.Target == "" {
+			//annotated types of the packages
+			err = generator.New().GenerateAnnotated(ctx, cfg.Package, cfg)
+		} else {
+			_, err = generator.New().Generate(ctx, cfg)
+		}
 		if err != nil {
 			exitOnError(err)
 		}
 	}
 
+	app.Command("regen", "regenerate every file with the generation header", func(cmd *cli.Cmd) {
+		patterns := cmd.StringsArg("PATTERN", []string{"./..."}, "directory, dir/... includes subdirectories")
+		cmd.Spec = "[PATTERN...]"
+		cmd.Action = func() {
+			if err := generator.New().Regenerate(context.Background(), *patterns); err != nil {
+				exitOnError(err)
+			}
+		}
+	})
+	app.Command("watch", "regenerate files with the generation header when their inputs change", func(cmd *cli.Cmd) {
+		patterns := cmd.StringsArg("PATTERN", []string{"./..."}, "directory, dir/... includes subdirectories")
+		interval := cmd.IntOpt("interval", 500, "polling interval, ms")
+		debounce := cmd.IntOpt("debounce", 300, "regenerate when inputs are unchanged for the period, ms")
+		cmd.Spec = "[OPTIONS] [PATTERN...]"
+		cmd.Action = func() {
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+			err := generator.Watch(ctx, *patterns, time.Duration(*interval)*time.Millisecond, time.Duration(*debounce)*time.Millisecond, os.Stdout)
+			if err != nil {
+				exitOnError(err)
+			}
+		}
+	})
+	app.Command("clean", "remove every file with the generation header", func(cmd *cli.Cmd) {
+		patterns := cmd.StringsArg("PATTERN", []string{"./..."}, "directory, dir/... includes subdirectories")
+		cmd.Spec = "[PATTERN...]"
+		cmd.Action = func() {
+			if err := generator.Clean(*patterns); err != nil {
+				exitOnError(err)
+			}
+		}
+	})
+
 	err = app.Run(os.Args)
 	if err != nil {
 		exitOnError(err)
@@ -0,0 +1,29 @@
+package annotated
+
+type Row struct {
+	ID int
+}
+
+//gosingl:generate variable=&db comment="Code generated by gosingl. DO NOT EDIT."
+type QueryExecutor struct {
+	Pool
+}
+
+func (q *QueryExecutor) Query(sql string) ([]Row, error) {
+	return nil, nil
+}
+
+type Pool struct {
+}
+
+func (p Pool) Close() error {
+	return nil
+}
+
+// Cache is not annotated
+type Cache struct {
+}
+
+func (c *Cache) Get(key string) string {
+	return ""
+}
@@ -0,0 +1,16 @@
+//gosingl:config {"version":"0.2.0","pkg":"github.com/alh1m1k/gosingl/test/annotated","target":"QueryExecutor","variable":"&db","suffix":"_singleton.go"}
+
+// Code generated by gosingl. DO NOT EDIT.
+package annotated
+
+var db *QueryExecutor
+
+// <QueryExecutor> from github.com/alh1m1k/gosingl/test/annotated
+
+func Query(sql string) ([]Row, error) {
+	return db.Query(sql)
+}
+
+func Close() error {
+	return db.Pool.Close()
+}
@@ -0,0 +1,24 @@
+package api
+
+import (
+	"io"
+	"strings"
+)
+
+type reader struct {
+	*strings.Reader
+}
+
+type closer interface {
+	io.Closer
+	Flush(force bool) error
+}
+
+type api struct {
+	reader
+	closer
+	OnClose func() error
+}
+
+func (receiver *api) Rewind(s string, _ int) {
+}
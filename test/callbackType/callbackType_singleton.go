@@ -1,3 +1,5 @@
+//gosingl:config {"version":"0.2.0","pkg":"github.com/alh1m1k/gosingl/test/callbackType","target":"CallbackType","variable":"cbInstance","suffix":"_singleton.go"}
+
 // random comment
 package callbackType
 
@@ -1,3 +1,5 @@
+//gosingl:config {"version":"0.2.0","pkg":"github.com/alh1m1k/gosingl/test/empty","target":"empty","variable":"Instance","suffix":"_singleton.go"}
+
 // Code generated by <git repo>. DO NOT EDIT.
 package empty
 
@@ -0,0 +1,13 @@
+package forward
+
+import "io"
+
+type level int
+
+type forward interface {
+	Log(lvl level, format string, args ...any)
+	Copy(io.Writer, io.Reader) (int64, error)
+	Skip(_ int, _ string, flag bool) bool
+	Join(sep string, parts ...string) string
+	Mark(stand int) //named as the receiver of the stand-in
+}
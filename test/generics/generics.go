@@ -37,6 +37,11 @@ func (receiver generics[T, R, Z]) Typed(a T, b R) {
 
 }
 
+// Do parameters are named as the receiver and the local of the fake
+func (receiver generics[T, R, Z]) Do(stub T, fake R) error {
+	return nil
+}
+
 func (receiver innerGenerics[F, _]) InnerTyped(a F) {
 
 }
@@ -1,3 +1,5 @@
+//gosingl:config {"version":"0.2.0","pkg":"github.com/alh1m1k/gosingl/test/generics","target":"generics","variable":"g[int, bool, *os.File]","suffix":"_singleton.go"}
+
 // Code generated by <git repo>. DO NOT EDIT.
 package generics
 
@@ -11,6 +13,10 @@ func Typed(a int, b bool) {
 	g.Typed(a, b)
 }
 
+func Do(stub int, fake bool) error {
+	return g.Do(stub, fake)
+}
+
 func InnerTyped(a int) {
 	g.innerGenerics.InnerTyped(a)
 }
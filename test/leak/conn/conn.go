@@ -0,0 +1,19 @@
+package conn
+
+type options struct {
+	retries int
+}
+
+type Conn struct{}
+
+func (c *Conn) Ping() error {
+	return nil
+}
+
+func (c *Conn) Dial(address string, opts *options) error {
+	return nil
+}
+
+func (c *Conn) Hooks() []func(*options) {
+	return nil
+}
@@ -0,0 +1,13 @@
+package leak
+
+import "github.com/alh1m1k/gosingl/test/leak/conn"
+
+type state int
+
+type Client struct {
+	conn.Conn
+}
+
+func (c *Client) State() state {
+	return 0
+}
@@ -0,0 +1,2 @@
+// Package facade holds facade of the outPackage.Store generated in other package
+package facade
@@ -0,0 +1,7 @@
+package store
+
+type Store struct{}
+
+func (s *Store) Get(key string) string {
+	return key
+}
@@ -0,0 +1,49 @@
+package outPackage
+
+import (
+	"io"
+	"time"
+)
+
+type Record struct {
+	Key   string
+	Value []byte
+}
+
+type options struct {
+	ttl time.Duration
+}
+
+type Store struct {
+	io.Closer
+	records map[string]Record
+}
+
+func (s *Store) Get(key string) (Record, bool) {
+	record, ok := s.records[key]
+	return record, ok
+}
+
+func (s *Store) Put(records ...Record) error {
+	for _, record := range records {
+		s.records[record.Key] = record
+	}
+	return nil
+}
+
+func (s *Store) Keys() []string {
+	keys := make([]string, 0, len(s.records))
+	for key := range s.records {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// Leaky refers to unexported type
+type Leaky struct{}
+
+func (l *Leaky) Configure(opts *options) {}
+
+type hidden struct{}
+
+func (h *hidden) Do() {}
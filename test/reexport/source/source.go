@@ -0,0 +1,55 @@
+package source
+
+import (
+	"io"
+	"time"
+)
+
+// Mode of the operation
+type Mode int
+
+type options struct{}
+
+// Reader reads with timeout
+type Reader interface {
+	io.Reader
+	Timeout() time.Duration
+}
+
+type Pair[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
+// supported modes
+const (
+	Fast Mode = iota
+	Safe
+	internalMode
+)
+
+const Version = "1.0"
+
+var (
+	DefaultTimeout = time.Second
+	ErrClosed      = io.ErrClosedPipe
+)
+
+// Open opens something
+func Open(name string, mode Mode, _ ...time.Duration) (Reader, error) {
+	return nil, nil
+}
+
+func Copy(io.Writer, Reader) (written int64, err error) {
+	return 0, nil
+}
+
+func Configure(opts *options) {}
+
+func First[T any](values ...T) T {
+	return values[0]
+}
+
+func InternalDebug() {}
+
+func helper() {}
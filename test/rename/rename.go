@@ -0,0 +1,22 @@
+package rename
+
+import "io"
+
+type resource struct{}
+
+func (r *resource) Close() error {
+	return nil
+}
+
+func (r *resource) Name() string {
+	return "resource"
+}
+
+type conn struct {
+	io.Closer
+	resource
+}
+
+func (c *conn) Open() error {
+	return nil
+}
@@ -0,0 +1,49 @@
+package rpc
+
+import (
+	"errors"
+	"time"
+)
+
+type ops struct{}
+
+func (ops) Div(a, b int) (int, error) {
+	if b == 0 {
+		return 0, errors.New("division by zero")
+	}
+	return a / b, nil
+}
+
+type calc struct {
+	ops
+	history []string
+}
+
+func (c *calc) Add(a, b int) int {
+	c.history = append(c.history, "add")
+	return a + b
+}
+
+func (c *calc) Sum(values ...float64) (sum float64, err error) {
+	for _, v := range values {
+		sum += v
+	}
+	return sum, nil
+}
+
+func (c *calc) Later(t time.Time, _ string) (time.Time, error) {
+	return t.Add(time.Hour), nil
+}
+
+// Connect parameters are named as the locals of the client
+func (c *calc) Connect(client, rpcArgs string) error {
+	c.history = append(c.history, client+rpcArgs)
+	return nil
+}
+
+func (c *calc) Reset() {
+	c.history = nil
+}
+
+func (c *calc) Watch(updates chan<- int) {
+}
@@ -0,0 +1,35 @@
+package rpc
+
+import (
+	"net"
+	"net/rpc"
+	"testing"
+	"time"
+)
+
+func TestRPC(t *testing.T) {
+	server := rpc.NewServer()
+	if err := RegisterCalcService(server, &calc{}); err != nil {
+		t.Fatal(err)
+	}
+	serverConn, clientConn := net.Pipe()
+	go server.ServeConn(serverConn)
+	client := rpc.NewClient(clientConn)
+	defer client.Close()
+
+	Client = NewCalcClient(client)
+
+	if sum, err := Sum(1, 2, 3.5); err != nil || sum != 6.5 {
+		t.Errorf("Sum(1, 2, 3.5) is %f, %v", sum, err)
+	}
+	now := time.Now()
+	if later, err := Later(now, ""); err != nil || !later.Equal(now.Add(time.Hour)) {
+		t.Errorf("Later(now) is %v, %v", later, err)
+	}
+	if _, err := Div(1, 0); err == nil || err.Error() != "division by zero" {
+		t.Errorf("Div(1, 0) error is %v", err)
+	}
+	if quotient, err := Div(9, 3); err != nil || quotient != 3 {
+		t.Errorf("Div(9, 3) is %d, %v", quotient, err)
+	}
+}
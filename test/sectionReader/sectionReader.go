@@ -0,0 +1,18 @@
+package sectionReader
+
+import (
+	"bufio"
+	"io"
+	"net/http"
+)
+
+type sectionReader struct {
+	*io.SectionReader
+	*bufio.ReadWriter
+	http.Header
+}
+
+func (s *sectionReader) Rewind() error {
+	_, err := s.Seek(0, io.SeekStart)
+	return err
+}
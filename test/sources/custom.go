@@ -0,0 +1,9 @@
+//gosingl:config {"version":"0.2.0","pkg":"github.com/alh1m1k/gosingl/test/sources","target":"Sources","variable":"Custom","suffix":"_singleton.go"}
+
+package sources
+
+var Custom *Sources
+
+func CustomName() string {
+	return Custom.Name()
+}
@@ -0,0 +1,3 @@
+package sources
+
+func debug() {}
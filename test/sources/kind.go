@@ -0,0 +1,3 @@
+package sources
+
+type Kind int
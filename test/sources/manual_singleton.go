@@ -0,0 +1,6 @@
+package sources
+
+// Manual is written by hand, suffix of the file does not make it the output
+func Manual() string {
+	return "manual"
+}
@@ -0,0 +1,7 @@
+package sources
+
+type Sources struct{}
+
+func (s *Sources) Name() string {
+	return "sources"
+}
@@ -0,0 +1,45 @@
+package via
+
+import (
+	"errors"
+	"io"
+	"os"
+)
+
+// Store is the part of the store which is exposed
+type Store interface {
+	io.Closer
+	Get(key string) (string, error)
+	Put(key, value string) error
+}
+
+type store struct {
+	io.Closer
+	items map[string]string
+}
+
+func (s *store) Get(name string) (string, error) {
+	if value, ok := s.items[name]; ok {
+		return value, nil
+	}
+	return "", errors.New("not found")
+}
+
+func (s *store) Put(name, value string) error {
+	s.items[name] = value
+	return nil
+}
+
+func (s *store) Reset() {
+	s.items = map[string]string{}
+}
+
+type file struct {
+	*os.File
+}
+
+type broken struct{}
+
+func (b broken) Get(key string) string {
+	return key
+}